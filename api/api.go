@@ -0,0 +1,134 @@
+// Пакет api надає JSON REST API для всіх калькуляторів під префіксом /api/v1.
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"Go_tutor/cable"
+	"Go_tutor/emissions"
+	"Go_tutor/fuel"
+	"Go_tutor/i18n"
+	"Go_tutor/load"
+	"Go_tutor/losses"
+	"Go_tutor/reliability"
+	"Go_tutor/shortcircuit"
+	"Go_tutor/solar"
+	"Go_tutor/validate"
+)
+
+// Prefix — спільний префікс усіх маршрутів API.
+const Prefix = "/api/v1/"
+
+// Коди помилок у тілі відповіді
+const (
+	CodeMethodNotAllowed = "method_not_allowed"
+	CodeInvalidJSON      = "invalid_json"
+	CodeInvalidInput     = "invalid_input"
+	CodeNotFound         = "not_found"
+	CodeInternal         = "internal"
+)
+
+// Error — тіло відповіді з помилкою. Fields містить повідомлення для
+// окремих полів запиту мовою клієнта.
+type Error struct {
+	Code    string            `json:"code"`
+	Message string            `json:"message"`
+	Fields  map[string]string `json:"fields,omitempty"`
+}
+
+type errorBody struct {
+	Error Error `json:"error"`
+}
+
+// Handler повертає обробник усіх маршрутів API.
+func Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(Prefix+"fuel", Endpoint(fuel.Calculate))
+	mux.Handle(Prefix+"mazut", Endpoint(fuel.CalculateMazut))
+	mux.Handle(Prefix+"fuel/bases", Endpoint(fuel.CalculateBases))
+	mux.Handle(Prefix+"fuel/batch", batchEndpoint("fuel-results.csv", fuel.Calculate))
+	mux.Handle(Prefix+"mazut/batch", batchEndpoint("mazut-results.csv", fuel.CalculateMazut))
+	mux.Handle(Prefix+"emissions", Endpoint(emissions.Calculate))
+	mux.Handle(Prefix+"emissions/blend", Endpoint(emissions.CalculateBlend))
+	mux.Handle(Prefix+"emissions/tax", Endpoint(calculateTax))
+	mux.Handle(Prefix+"solar", Endpoint(solar.Calculate))
+	mux.Handle(Prefix+"solar/fit", Endpoint(solar.FitErrors))
+	mux.Handle(Prefix+"solar/hourly", Endpoint(solar.CalculateHourly))
+	mux.Handle(Prefix+"solar/simulate", Endpoint(solar.Simulate))
+	mux.Handle(Prefix+"cable", Endpoint(cable.Calculate))
+	mux.Handle(Prefix+"short-circuit", Endpoint(shortcircuit.Calculate))
+	mux.Handle(Prefix+"reliability", Endpoint(reliability.Calculate))
+	mux.Handle(Prefix+"losses", Endpoint(losses.Calculate))
+	mux.Handle(Prefix+"load", Endpoint(load.Calculate))
+	mux.HandleFunc(Prefix+"fuels", fuelsHandler)
+	mux.HandleFunc(Prefix+"fuels/{name}", fuelHandler)
+	mux.HandleFunc(Prefix+"ledger", ledgerHandler)
+	mux.HandleFunc(Prefix+"ledger/summary", ledgerSummaryHandler)
+	mux.HandleFunc(Prefix+"ledger/{id}", ledgerEntryHandler)
+	mux.HandleFunc(Prefix+"permits", permitsHandler)
+	mux.HandleFunc(Prefix+"permits/{plant}", permitHandler)
+	mux.HandleFunc(Prefix+"permits/{plant}/check", permitCheckHandler)
+	mux.HandleFunc(Prefix+"tax/rates", taxRatesHandler)
+	mux.HandleFunc(Prefix+"tax/rates/{year}", taxRateHandler)
+	mux.HandleFunc(Prefix, func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, CodeNotFound, i18n.FromRequest(w, r).T("unknown calculator"))
+	})
+	return mux
+}
+
+// Endpoint перетворює функцію розрахунку на JSON-обробник.
+func Endpoint[In, Out any](calculate func(In) (Out, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		loc := i18n.FromRequest(w, r)
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, loc.T("only POST method is supported"))
+			return
+		}
+
+		var input In
+		if !decode(w, r, &input) {
+			return
+		}
+
+		result, err := calculate(input)
+		if err != nil {
+			writeInputError(w, loc, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, result)
+	}
+}
+
+// decode читає JSON-тіло запиту й відповідає помилкою, якщо воно некоректне.
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, CodeInvalidJSON, err.Error())
+		return false
+	}
+	return true
+}
+
+// writeInputError відповідає 422 з перекладеними повідомленнями перевірки.
+func writeInputError(w http.ResponseWriter, loc i18n.Localizer, err error) {
+	body := Error{Code: CodeInvalidInput, Message: loc.T(err.Error())}
+	if fields, ok := validate.Fields(err); ok {
+		body.Message = loc.T("input validation failed")
+		body.Fields = fields.Translate(loc.Format)
+	}
+	writeJSON(w, http.StatusUnprocessableEntity, errorBody{body})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, errorBody{Error{Code: code, Message: message}})
+}
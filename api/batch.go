@@ -0,0 +1,59 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"Go_tutor/batch"
+	"Go_tutor/i18n"
+)
+
+// batchEndpoint приймає таблицю зразків CSV або XLSX у полі file
+// multipart-форми чи в тілі запиту, виконує calculate для кожного рядка й
+// повертає CSV-файл name з результатами та помилками рядків.
+func batchEndpoint[In, Out any](name string, calculate func(In) (Out, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		loc := i18n.FromRequest(w, r)
+		if r.Method != http.MethodPost {
+			methodNotAllowed(w, loc, http.MethodPost)
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, batch.MaxSize)
+		data, err := readUpload(r)
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, CodeInvalidInput, loc.T("the file is too large"))
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusBadRequest, CodeInvalidInput, err.Error())
+			return
+		}
+		table, err := batch.Read(data)
+		if err != nil {
+			writeInputError(w, loc, err)
+			return
+		}
+
+		rows := batch.Run(table, calculate)
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="`+name+`"`)
+		batch.Write(w, table, rows, loc.Error)
+	}
+}
+
+// readUpload повертає файл з поля file multipart-форми або тіло запиту.
+func readUpload(r *http.Request) ([]byte, error) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		return io.ReadAll(r.Body)
+	}
+	f, _, err := r.FormFile("file")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
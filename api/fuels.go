@@ -0,0 +1,81 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"Go_tutor/emissions"
+	"Go_tutor/i18n"
+	"Go_tutor/validate"
+)
+
+// fuelsHandler повертає довідник палива (GET) або додає чи оновлює марку
+// (POST).
+func fuelsHandler(w http.ResponseWriter, r *http.Request) {
+	loc := i18n.FromRequest(w, r)
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, emissions.Default.List())
+	case http.MethodPost:
+		var grade emissions.Grade
+		if decode(w, r, &grade) {
+			putGrade(w, loc, grade)
+		}
+	default:
+		methodNotAllowed(w, loc, http.MethodGet, http.MethodPost)
+	}
+}
+
+// fuelHandler працює з однією маркою палива: GET, PUT та DELETE.
+func fuelHandler(w http.ResponseWriter, r *http.Request) {
+	loc := i18n.FromRequest(w, r)
+	name := r.PathValue("name")
+	switch r.Method {
+	case http.MethodGet:
+		grade, ok := emissions.Default.Get(name)
+		if !ok {
+			writeError(w, http.StatusNotFound, CodeNotFound, loc.T(emissions.ErrUnknownFuel.Error()))
+			return
+		}
+		writeJSON(w, http.StatusOK, grade)
+	case http.MethodPut:
+		var grade emissions.Grade
+		if decode(w, r, &grade) {
+			grade.Name = name
+			putGrade(w, loc, grade)
+		}
+	case http.MethodDelete:
+		if err := emissions.Default.Delete(name); err == emissions.ErrUnknownFuel {
+			writeError(w, http.StatusNotFound, CodeNotFound, loc.T(err.Error()))
+		} else if err != nil {
+			writeError(w, http.StatusInternalServerError, CodeInternal, err.Error())
+		} else {
+			w.WriteHeader(http.StatusNoContent)
+		}
+	default:
+		methodNotAllowed(w, loc, http.MethodGet, http.MethodPut, http.MethodDelete)
+	}
+}
+
+func putGrade(w http.ResponseWriter, loc i18n.Localizer, grade emissions.Grade) {
+	created, err := emissions.Default.Put(grade)
+	if err != nil {
+		if _, ok := validate.Fields(err); ok {
+			writeInputError(w, loc, err)
+		} else {
+			writeError(w, http.StatusInternalServerError, CodeInternal, err.Error())
+		}
+		return
+	}
+	grade, _ = emissions.Default.Get(strings.TrimSpace(grade.Name))
+	if created {
+		writeJSON(w, http.StatusCreated, grade)
+	} else {
+		writeJSON(w, http.StatusOK, grade)
+	}
+}
+
+func methodNotAllowed(w http.ResponseWriter, loc i18n.Localizer, methods ...string) {
+	w.Header().Set("Allow", strings.Join(methods, ", "))
+	writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, loc.T("method is not allowed"))
+}
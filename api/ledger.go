@@ -0,0 +1,138 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"Go_tutor/i18n"
+	"Go_tutor/ledger"
+	"Go_tutor/validate"
+)
+
+// Запис журналу з перевіркою дозволу електростанції
+type ledgerEntry struct {
+	ledger.Entry
+	Checks []ledger.Check `json:"checks,omitempty"`
+}
+
+// ledgerHandler повертає записи журналу викидів (GET) або додає запис
+// (POST).
+func ledgerHandler(w http.ResponseWriter, r *http.Request) {
+	loc := i18n.FromRequest(w, r)
+	switch r.Method {
+	case http.MethodGet:
+		f, err := ledgerFilter(r)
+		if err != nil {
+			writeInputError(w, loc, err)
+			return
+		}
+		entries := ledger.Default.List(f)
+		if entries == nil {
+			entries = []ledger.Entry{}
+		}
+		writeJSON(w, http.StatusOK, entries)
+	case http.MethodPost:
+		var entry ledger.Entry
+		if !decode(w, r, &entry) {
+			return
+		}
+		entry, err := ledger.Default.Add(entry)
+		if err != nil {
+			writeStoreError(w, loc, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, ledgerEntry{entry, ledger.Default.CheckEntry(entry)})
+	default:
+		methodNotAllowed(w, loc, http.MethodGet, http.MethodPost)
+	}
+}
+
+// ledgerEntryHandler працює з одним записом журналу: GET, PUT та DELETE.
+func ledgerEntryHandler(w http.ResponseWriter, r *http.Request) {
+	loc := i18n.FromRequest(w, r)
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, CodeNotFound, loc.T(ledger.ErrNotFound.Error()))
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		entry, ok := ledger.Default.Get(id)
+		if !ok {
+			writeError(w, http.StatusNotFound, CodeNotFound, loc.T(ledger.ErrNotFound.Error()))
+			return
+		}
+		writeJSON(w, http.StatusOK, entry)
+	case http.MethodPut:
+		var entry ledger.Entry
+		if !decode(w, r, &entry) {
+			return
+		}
+		entry, err := ledger.Default.Update(id, entry)
+		if err != nil {
+			writeStoreError(w, loc, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, ledgerEntry{entry, ledger.Default.CheckEntry(entry)})
+	case http.MethodDelete:
+		if err := ledger.Default.Delete(id); err != nil {
+			writeStoreError(w, loc, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		methodNotAllowed(w, loc, http.MethodGet, http.MethodPut, http.MethodDelete)
+	}
+}
+
+// ledgerSummaryHandler повертає викиди з початку року. Рік за
+// замовчуванням — поточний, month обмежує період.
+func ledgerSummaryHandler(w http.ResponseWriter, r *http.Request) {
+	loc := i18n.FromRequest(w, r)
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, loc, http.MethodGet)
+		return
+	}
+	f, err := ledgerFilter(r)
+	if err != nil {
+		writeInputError(w, loc, err)
+		return
+	}
+	if f.Year == 0 {
+		f.Year = time.Now().Year()
+	}
+	writeJSON(w, http.StatusOK, ledger.Default.Summary(f))
+}
+
+// ledgerFilter читає відбір записів із параметрів запиту plant, block,
+// year та month.
+func ledgerFilter(r *http.Request) (ledger.Filter, error) {
+	q := r.URL.Query()
+	f := ledger.Filter{Plant: q.Get("plant"), Block: q.Get("block")}
+	errs := validate.Errors{}
+	for field, dst := range map[string]*int{"year": &f.Year, "month": &f.Month} {
+		if s := q.Get(field); s != "" {
+			v, err := strconv.Atoi(s)
+			if err != nil {
+				errs.Add(field, "must be a whole number")
+			}
+			*dst = v
+		}
+	}
+	if f.Month != 0 {
+		errs.Range("month", float64(f.Month), 1, 12)
+	}
+	return f, errs.Err()
+}
+
+// writeStoreError відповідає на помилку запису до журналу.
+func writeStoreError(w http.ResponseWriter, loc i18n.Localizer, err error) {
+	if err == ledger.ErrNotFound || err == ledger.ErrNoPermit {
+		writeError(w, http.StatusNotFound, CodeNotFound, loc.T(err.Error()))
+	} else if _, ok := validate.Fields(err); ok {
+		writeInputError(w, loc, err)
+	} else {
+		writeError(w, http.StatusInternalServerError, CodeInternal, err.Error())
+	}
+}
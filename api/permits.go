@@ -0,0 +1,120 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"Go_tutor/emissions"
+	"Go_tutor/i18n"
+	"Go_tutor/ledger"
+	"Go_tutor/validate"
+)
+
+// Результат розрахунку суміші з перевіркою дозволу
+type permitCheck struct {
+	Result emissions.BlendResult `json:"result"`
+	Checks []ledger.Check        `json:"checks"`
+}
+
+// permitsHandler повертає дозволи на викиди (GET) або додає чи оновлює
+// дозвіл (POST).
+func permitsHandler(w http.ResponseWriter, r *http.Request) {
+	loc := i18n.FromRequest(w, r)
+	switch r.Method {
+	case http.MethodGet:
+		permits := ledger.Default.Permits()
+		if permits == nil {
+			permits = []ledger.Permit{}
+		}
+		writeJSON(w, http.StatusOK, permits)
+	case http.MethodPost:
+		var permit ledger.Permit
+		if decode(w, r, &permit) {
+			putPermit(w, loc, permit)
+		}
+	default:
+		methodNotAllowed(w, loc, http.MethodGet, http.MethodPost)
+	}
+}
+
+// permitHandler працює з дозволом однієї електростанції: GET, PUT та
+// DELETE.
+func permitHandler(w http.ResponseWriter, r *http.Request) {
+	loc := i18n.FromRequest(w, r)
+	plant := r.PathValue("plant")
+	switch r.Method {
+	case http.MethodGet:
+		permit, ok := ledger.Default.Permit(plant)
+		if !ok {
+			writeError(w, http.StatusNotFound, CodeNotFound, loc.T(ledger.ErrNoPermit.Error()))
+			return
+		}
+		writeJSON(w, http.StatusOK, permit)
+	case http.MethodPut:
+		var permit ledger.Permit
+		if decode(w, r, &permit) {
+			permit.Plant = plant
+			putPermit(w, loc, permit)
+		}
+	case http.MethodDelete:
+		if err := ledger.Default.DeletePermit(plant); err != nil {
+			writeStoreError(w, loc, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		methodNotAllowed(w, loc, http.MethodGet, http.MethodPut, http.MethodDelete)
+	}
+}
+
+// permitCheckHandler розраховує викиди суміші палив і перевіряє їх разом
+// із записами журналу за рік year (за замовчуванням поточний) на
+// відповідність дозволу.
+func permitCheckHandler(w http.ResponseWriter, r *http.Request) {
+	loc := i18n.FromRequest(w, r)
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, loc, http.MethodPost)
+		return
+	}
+	year := time.Now().Year()
+	if s := r.URL.Query().Get("year"); s != "" {
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			writeInputError(w, loc, validate.Errors{"year": {Format: "must be a whole number"}})
+			return
+		}
+		year = v
+	}
+
+	var input emissions.BlendInput
+	if !decode(w, r, &input) {
+		return
+	}
+	result, err := emissions.CalculateBlend(input)
+	if err != nil {
+		writeInputError(w, loc, err)
+		return
+	}
+	checks, err := ledger.Default.Check(r.PathValue("plant"), year, result.Total)
+	if err != nil {
+		writeError(w, http.StatusNotFound, CodeNotFound, loc.T(err.Error()))
+		return
+	}
+	writeJSON(w, http.StatusOK, permitCheck{result, checks})
+}
+
+func putPermit(w http.ResponseWriter, loc i18n.Localizer, permit ledger.Permit) {
+	created, err := ledger.Default.PutPermit(permit)
+	if err != nil {
+		writeStoreError(w, loc, err)
+		return
+	}
+	permit, _ = ledger.Default.Permit(strings.TrimSpace(permit.Plant))
+	if created {
+		writeJSON(w, http.StatusCreated, permit)
+	} else {
+		writeJSON(w, http.StatusOK, permit)
+	}
+}
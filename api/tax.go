@@ -0,0 +1,110 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"Go_tutor/emissions"
+	"Go_tutor/i18n"
+	"Go_tutor/tax"
+	"Go_tutor/validate"
+)
+
+// Вхідні дані розрахунку податку: суміш палив і рік, за ставками якого
+// розраховується податок (за замовчуванням поточний).
+type taxInput struct {
+	Year int `json:"year,omitempty"`
+	emissions.BlendInput
+}
+
+// Викиди суміші палив і податок за них
+type taxResult struct {
+	Result emissions.BlendResult `json:"result"`
+	Tax    tax.Tax               `json:"tax"`
+}
+
+// calculateTax розраховує викиди суміші палив і екологічний податок.
+func calculateTax(in taxInput) (taxResult, error) {
+	if in.Year == 0 {
+		in.Year = time.Now().Year()
+	}
+	result, err := emissions.CalculateBlend(in.BlendInput)
+	if err != nil {
+		return taxResult{}, err
+	}
+	t, err := tax.Default.Calculate(in.Year, result.Total)
+	if err != nil {
+		return taxResult{}, validate.Errors{"year": {Format: err.Error()}}
+	}
+	return taxResult{result, t}, nil
+}
+
+// taxRatesHandler повертає ставки податку (GET) або додає чи оновлює
+// ставки року (POST).
+func taxRatesHandler(w http.ResponseWriter, r *http.Request) {
+	loc := i18n.FromRequest(w, r)
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, tax.Default.List())
+	case http.MethodPost:
+		var s tax.Schedule
+		if decode(w, r, &s) {
+			putSchedule(w, loc, s)
+		}
+	default:
+		methodNotAllowed(w, loc, http.MethodGet, http.MethodPost)
+	}
+}
+
+// taxRateHandler працює зі ставками одного року: GET, PUT та DELETE.
+func taxRateHandler(w http.ResponseWriter, r *http.Request) {
+	loc := i18n.FromRequest(w, r)
+	year, err := strconv.Atoi(r.PathValue("year"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, CodeNotFound, loc.T(tax.ErrNoRates.Error()))
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		s, ok := tax.Default.Get(year)
+		if !ok {
+			writeError(w, http.StatusNotFound, CodeNotFound, loc.T(tax.ErrNoRates.Error()))
+			return
+		}
+		writeJSON(w, http.StatusOK, s)
+	case http.MethodPut:
+		var s tax.Schedule
+		if decode(w, r, &s) {
+			s.Year = year
+			putSchedule(w, loc, s)
+		}
+	case http.MethodDelete:
+		if err := tax.Default.Delete(year); err == tax.ErrNoRates {
+			writeError(w, http.StatusNotFound, CodeNotFound, loc.T(err.Error()))
+		} else if err != nil {
+			writeError(w, http.StatusInternalServerError, CodeInternal, err.Error())
+		} else {
+			w.WriteHeader(http.StatusNoContent)
+		}
+	default:
+		methodNotAllowed(w, loc, http.MethodGet, http.MethodPut, http.MethodDelete)
+	}
+}
+
+func putSchedule(w http.ResponseWriter, loc i18n.Localizer, s tax.Schedule) {
+	created, err := tax.Default.Put(s)
+	if err != nil {
+		if _, ok := validate.Fields(err); ok {
+			writeInputError(w, loc, err)
+		} else {
+			writeError(w, http.StatusInternalServerError, CodeInternal, err.Error())
+		}
+		return
+	}
+	if created {
+		writeJSON(w, http.StatusCreated, s)
+	} else {
+		writeJSON(w, http.StatusOK, s)
+	}
+}
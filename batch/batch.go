@@ -0,0 +1,309 @@
+// Пакет batch виконує розрахунок калькулятора для кожного рядка таблиці
+// зразків (CSV або XLSX) і записує результати в CSV.
+package batch
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"Go_tutor/number"
+	"Go_tutor/validate"
+)
+
+// Обмеження розміру таблиці: байтів файлу та рядків зразків
+const (
+	MaxSize = 10 << 20
+	MaxRows = 10000
+)
+
+var (
+	ErrEmpty       = errors.New("the table has no header row")
+	ErrTooManyRows = errors.New("the table has too many rows")
+)
+
+// Стовпець з повідомленням про помилку рядка
+const ErrorColumn = "error"
+
+// Table — заголовок і рядки таблиці. Comma — роздільник стовпців
+// вихідного CSV, з яким записуються і результати.
+type Table struct {
+	Header []string
+	Rows   [][]string
+	Comma  rune
+}
+
+// Field — обчислене поле результату. Вкладені поля мають назви через
+// крапку, наприклад combustion.v0.
+type Field struct {
+	Name  string
+	Value string
+}
+
+// Row — результат розрахунку одного рядка таблиці.
+type Row struct {
+	Values []string
+	Result []Field
+	Err    error
+}
+
+// Read розбирає таблицю XLSX або CSV з роздільником "," чи ";". Формат
+// визначається за вмістом, порожні рядки пропускаються.
+func Read(data []byte) (Table, error) {
+	var t Table
+	var err error
+	if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		t.Comma = ','
+		t.Rows, err = readXLSX(data)
+	} else {
+		data = bytes.TrimPrefix(data, []byte("\ufeff"))
+		t.Comma = sniffComma(data)
+		r := csv.NewReader(bytes.NewReader(data))
+		r.Comma = t.Comma
+		r.FieldsPerRecord = -1
+		t.Rows, err = r.ReadAll()
+	}
+	if err != nil {
+		return Table{}, err
+	}
+
+	rows := t.Rows[:0]
+	for _, row := range t.Rows {
+		for _, v := range row {
+			if strings.TrimSpace(v) != "" {
+				rows = append(rows, row)
+				break
+			}
+		}
+	}
+	if len(rows) == 0 {
+		return Table{}, ErrEmpty
+	}
+	if len(rows)-1 > MaxRows {
+		return Table{}, ErrTooManyRows
+	}
+	t.Header, t.Rows = rows[0], rows[1:]
+	for i, name := range t.Header {
+		t.Header[i] = strings.ToLower(strings.TrimSpace(name))
+	}
+	return t, nil
+}
+
+// sniffComma обирає роздільник за першим рядком: Excel з українськими
+// налаштуваннями зберігає CSV з крапкою з комою.
+func sniffComma(data []byte) rune {
+	line, _, _ := bytes.Cut(data, []byte("\n"))
+	if bytes.Count(line, []byte(";")) > bytes.Count(line, []byte(",")) {
+		return ';'
+	}
+	return ','
+}
+
+// Run виконує calculate для кожного рядка. Стовпці, назви яких
+// збігаються з JSON-полями In, стають вхідними даними; решта (номер
+// зразка, дата тощо) лише переноситься в результат.
+func Run[In, Out any](t Table, calculate func(In) (Out, error)) []Row {
+	inputs := make([]bool, len(t.Header))
+	for i, name := range t.Header {
+		inputs[i] = IsField[In](name)
+	}
+
+	rows := make([]Row, len(t.Rows))
+	for n, values := range t.Rows {
+		rows[n].Values = values
+		fields := make(map[string]string, len(values))
+		for i, v := range values {
+			if i < len(inputs) && inputs[i] {
+				fields[t.Header[i]] = v
+			}
+		}
+
+		in, err := Decode[In](fields)
+		if err != nil {
+			rows[n].Err = err
+			continue
+		}
+		out, err := calculate(in)
+		if err != nil {
+			rows[n].Err = err
+			continue
+		}
+		rows[n].Result, rows[n].Err = Flatten(out)
+	}
+	return rows
+}
+
+// Decode заповнює вхідні дані з текстових значень полів за їхніми
+// JSON-назвами. Числа можна записувати з комою або крапкою, порожні
+// значення пропускаються.
+func Decode[In any](fields map[string]string) (In, error) {
+	obj := make(map[string]any, len(fields))
+	for name, v := range fields {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			// Цілі числа передаються без перетворення на float64, щоб не
+			// втратити точність великих значень; запис нормалізується, бо
+			// «05» чи «+5» не є числами JSON
+			obj[name] = json.Number(strconv.FormatInt(n, 10))
+		} else if f, err := number.Parse(v); err == nil {
+			obj[name] = f
+		} else {
+			obj[name] = v
+		}
+	}
+
+	var in In
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return in, err
+	}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.DisallowUnknownFields()
+	if err := d.Decode(&in); err != nil {
+		return in, inputError(err)
+	}
+	return in, nil
+}
+
+// inputError замінює помилку розбору текстового значення в числовому
+// стовпці на повідомлення, як у вебформах.
+func inputError(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Value == "string" {
+		errs := validate.Errors{}
+		errs.Add(typeErr.Field, "must be a number")
+		return errs
+	}
+	return err
+}
+
+// IsField перевіряє, чи є name назвою JSON-поля типу In.
+func IsField[In any](name string) bool {
+	if name == "" {
+		return false
+	}
+	var in In
+	d := json.NewDecoder(strings.NewReader(`{` + strconv.Quote(name) + `:null}`))
+	d.DisallowUnknownFields()
+	return d.Decode(&in) == nil
+}
+
+// Flatten перетворює результат на плоский список полів у порядку JSON.
+// Масиви (як-от доданки формул) до таблиці не потрапляють.
+func Flatten(v any) ([]Field, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	var fields []Field
+	err = walk(d, "", &fields)
+	return fields, err
+}
+
+func walk(d *json.Decoder, name string, fields *[]Field) error {
+	tok, err := d.Token()
+	if err != nil {
+		return err
+	}
+	switch tok := tok.(type) {
+	case json.Delim:
+		if tok == '[' {
+			for d.More() {
+				if err := walk(d, "", new([]Field)); err != nil {
+					return err
+				}
+			}
+			_, err = d.Token()
+			return err
+		}
+		for d.More() {
+			key, err := d.Token()
+			if err != nil {
+				return err
+			}
+			child := key.(string)
+			if name != "" {
+				child = name + "." + child
+			}
+			if err := walk(d, child, fields); err != nil {
+				return err
+			}
+		}
+		_, err = d.Token()
+		return err
+	case json.Number:
+		if _, err := tok.Int64(); err == nil {
+			*fields = append(*fields, Field{name, tok.String()})
+			return nil
+		}
+		f, err := tok.Float64()
+		if err != nil {
+			return err
+		}
+		*fields = append(*fields, Field{name, strconv.FormatFloat(math.Round(f*1e6)/1e6, 'f', -1, 64)})
+	case string:
+		*fields = append(*fields, Field{name, tok})
+	case bool:
+		*fields = append(*fields, Field{name, strconv.FormatBool(tok)})
+	}
+	return nil
+}
+
+// Write записує CSV: вихідні стовпці, обчислені стовпці в порядку першої
+// появи та стовпець error з повідомленням, перекладеним message.
+// Обчислений стовпець, назва якого збігається з вихідним, отримує префікс
+// "result.". Якщо роздільник — крапка з комою, числа записуються з
+// десятковою комою.
+func Write(w io.Writer, t Table, rows []Row, message func(error) string) error {
+	inputs := make(map[string]bool, len(t.Header))
+	for _, name := range t.Header {
+		inputs[name] = true
+	}
+	var columns []string
+	index := map[string]int{}
+	for _, row := range rows {
+		for _, f := range row.Result {
+			if _, ok := index[f.Name]; !ok {
+				index[f.Name] = len(columns)
+				name := f.Name
+				if inputs[name] {
+					name = "result." + name
+				}
+				columns = append(columns, name)
+			}
+		}
+	}
+
+	// BOM, щоб Excel розпізнав UTF-8
+	io.WriteString(w, "\ufeff")
+	cw := csv.NewWriter(w)
+	cw.Comma = t.Comma
+	cw.Write(append(append(append([]string(nil), t.Header...), columns...), ErrorColumn))
+	for _, row := range rows {
+		rec := make([]string, len(t.Header)+len(columns)+1)
+		copy(rec, row.Values)
+		for _, f := range row.Result {
+			v := f.Value
+			if _, err := strconv.ParseFloat(v, 64); err == nil && t.Comma == ';' {
+				v = strings.Replace(v, ".", ",", 1)
+			}
+			rec[len(t.Header)+index[f.Name]] = v
+		}
+		if row.Err != nil {
+			rec[len(rec)-1] = message(row.Err)
+		}
+		cw.Write(rec)
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,83 @@
+package batch
+
+import (
+	"errors"
+	"testing"
+
+	"Go_tutor/validate"
+)
+
+type sample struct {
+	Value float64 `json:"value"`
+	Count int     `json:"count"`
+	Seed  int64   `json:"seed"`
+}
+
+func TestDecode(t *testing.T) {
+	in, err := Decode[sample](map[string]string{"value": "1,5", "count": " 3 ", "seed": "9007199254740993"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2^53 + 1 не можна точно подати як float64
+	want := sample{Value: 1.5, Count: 3, Seed: 9007199254740993}
+	if in != want {
+		t.Errorf("Decode = %+v, want %+v", in, want)
+	}
+}
+
+// Цілі з нулями попереду, знаком «+» чи «-0» не є числами JSON і мають
+// бути нормалізовані.
+func TestDecodeIntegerForms(t *testing.T) {
+	tests := []struct {
+		in   string
+		want sample
+	}{
+		{"05", sample{Value: 5, Count: 5, Seed: 5}},
+		{"+5", sample{Value: 5, Count: 5, Seed: 5}},
+		{"-0", sample{}},
+		{"007", sample{Value: 7, Count: 7, Seed: 7}},
+	}
+	for _, tt := range tests {
+		got, err := Decode[sample](map[string]string{"value": tt.in, "count": tt.in, "seed": tt.in})
+		if err != nil {
+			t.Errorf("%q: %v", tt.in, err)
+		} else if got != tt.want {
+			t.Errorf("%q: Decode = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	_, err := Decode[sample](map[string]string{"value": "x"})
+	errs, ok := validate.Fields(err)
+	if !ok || errs["value"].Format != "must be a number" {
+		t.Errorf("Decode error = %v", err)
+	}
+	_, err = Decode[sample](map[string]string{"other": "1"})
+	if err == nil || errors.As(err, new(validate.Errors)) {
+		t.Errorf("unknown field error = %v", err)
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	fields, err := Flatten(struct {
+		Seed  int64     `json:"seed"`
+		Value float64   `json:"value"`
+		Terms []float64 `json:"terms"`
+		Inner struct {
+			Name string `json:"name"`
+		} `json:"inner"`
+	}{Seed: 9007199254740993, Value: 1.0 / 3, Terms: []float64{1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Field{{"seed", "9007199254740993"}, {"value", "0.333333"}, {"inner.name", ""}}
+	if len(fields) != len(want) {
+		t.Fatalf("Flatten = %v, want %v", fields, want)
+	}
+	for i := range want {
+		if fields[i] != want[i] {
+			t.Errorf("field %d = %v, want %v", i, fields[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// Пакет cable підбирає переріз кабелю за економічною густиною струму та
+// перевіряє його на термічну стійкість.
+package cable
+
+import (
+	"math"
+
+	"Go_tutor/validate"
+)
+
+// Вхідні дані
+type Input struct {
+	Sm   float64 `json:"sm"`   // Розрахункове навантаження (кВА)
+	Unom float64 `json:"unom"` // Номінальна напруга (кВ)
+	Kz   float64 `json:"kz"`   // Струм КЗ (кА)
+	Ft   float64 `json:"ft"`   // Фіктивний час вимикання (с)
+	Jek  float64 `json:"jek"`  // Економічна густина струму (А/мм²)
+	Ct   float64 `json:"ct"`   // Коефіцієнт термічної стійкості (А·√с/мм²)
+}
+
+// Результати
+type Result struct {
+	Izm    float64 `json:"izm"`     // Розрахунковий струм (А)
+	IzmMax float64 `json:"izm_max"` // Післяаварійний струм (А)
+	Sek    float64 `json:"sek"`     // Економічний переріз (мм²)
+	Smin   float64 `json:"smin"`    // Мінімальний переріз за термічною стійкістю (мм²)
+	Valid  bool    `json:"valid"`   // Економічний переріз не менший за мінімальний
+}
+
+// Validate перевіряє вхідні дані.
+func (in Input) Validate() error {
+	errs := validate.Errors{}
+	errs.Positive("sm", in.Sm)
+	errs.Positive("unom", in.Unom)
+	errs.NonNegative("kz", in.Kz)
+	errs.NonNegative("ft", in.Ft)
+	errs.Positive("jek", in.Jek)
+	errs.Positive("ct", in.Ct)
+	return errs.Err()
+}
+
+// Calculate визначає струми та перерізи кабелю.
+func Calculate(in Input) (Result, error) {
+	if err := in.Validate(); err != nil {
+		return Result{}, err
+	}
+
+	Izm := in.Sm / (math.Sqrt(3) * in.Unom) * 1000.0 // Convert to Amps
+	IzmMax := 2 * Izm
+	Sek := Izm / in.Jek
+	Smin := (in.Kz * 1000.0 * math.Sqrt(in.Ft)) / in.Ct
+	return Result{
+		Izm:    Izm,
+		IzmMax: IzmMax,
+		Sek:    Sek,
+		Smin:   Smin,
+		Valid:  Sek >= Smin,
+	}, nil
+}
@@ -0,0 +1,294 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+
+	"Go_tutor/batch"
+	"Go_tutor/emissions"
+	"Go_tutor/i18n"
+)
+
+// command — підкоманда одного калькулятора.
+type command struct {
+	summary string
+	catalog bool
+	run     func(name string, args []string, stdin io.Reader, stdout, stderr io.Writer) int
+}
+
+// withCatalog додає команді прапорець -catalog з файлом довідника палива.
+func withCatalog(c command) command {
+	c.catalog = true
+	return c
+}
+
+// Результат розрахунку одного набору вхідних даних
+type outcome struct {
+	Result any    `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// newCommand створює команду, що викликає calculate — ту саму функцію, що
+// й вебсторінки та API.
+func newCommand[In, Out any](summary string, calculate func(In) (Out, error)) command {
+	c := command{summary: summary}
+	c.run = func(name string, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+		fs := flag.NewFlagSet("calc "+name, flag.ContinueOnError)
+		fs.SetOutput(stderr)
+		format := fs.String("format", "table", "формат виводу: table або json")
+		lang := fs.String("lang", string(i18n.Default), "мова повідомлень про помилки: uk або en")
+		var catalog *string
+		if c.catalog {
+			catalog = fs.String("catalog", "", "файл довідника палива (.json або .csv); порожній — вбудований довідник")
+		}
+		values := map[string]string{}
+		for _, field := range inputFields(reflect.TypeFor[In]()) {
+			fs.Func(field, "поле "+field, func(v string) error {
+				values[field] = v
+				return nil
+			})
+		}
+		if err := fs.Parse(args); err != nil {
+			return 2
+		}
+		if *format != "table" && *format != "json" {
+			fmt.Fprintf(stderr, "calc %s: невідомий формат %q\n", name, *format)
+			return 2
+		}
+		l, ok := i18n.Parse(*lang)
+		if !ok {
+			fmt.Fprintf(stderr, "calc %s: невідома мова %q\n", name, *lang)
+			return 2
+		}
+		loc := i18n.Localizer{Lang: l}
+		if catalog != nil && *catalog != "" {
+			// Open створює відсутній файл, тому спершу перевіряємо, що він є
+			if _, err := os.Stat(*catalog); err != nil {
+				fmt.Fprintf(stderr, "calc %s: %v\n", name, err)
+				return 1
+			}
+			if err := emissions.Default.Open(*catalog); err != nil {
+				fmt.Fprintf(stderr, "calc %s: %v\n", name, err)
+				return 1
+			}
+		}
+
+		var inputs []In
+		var errs []error
+		var labels *table
+		if len(values) > 0 {
+			in, err := batch.Decode[In](values)
+			inputs, errs = []In{in}, []error{err}
+		} else {
+			var err error
+			if inputs, errs, labels, err = readInputs[In](stdin); err != nil {
+				fmt.Fprintf(stderr, "calc %s: %v\n", name, err)
+				return 1
+			}
+		}
+
+		outcomes := make([]outcome, len(inputs))
+		status := 0
+		for i, in := range inputs {
+			err := errs[i]
+			if err == nil {
+				var out Out
+				if out, err = calculate(in); err == nil {
+					outcomes[i].Result = out
+				}
+			}
+			if err != nil {
+				outcomes[i].Error = loc.Error(err)
+				status = 1
+			}
+		}
+
+		if *format == "json" {
+			enc := json.NewEncoder(stdout)
+			enc.SetIndent("", "  ")
+			if len(values) > 0 || len(outcomes) == 1 && labels == nil {
+				if outcomes[0].Error != "" {
+					fmt.Fprintf(stderr, "calc %s: %s\n", name, outcomes[0].Error)
+					return status
+				}
+				enc.Encode(outcomes[0].Result)
+				return status
+			}
+			enc.Encode(outcomes)
+			return status
+		}
+		if err := writeTable(stdout, outcomes, labels); err != nil {
+			fmt.Fprintf(stderr, "calc %s: %v\n", name, err)
+			return 1
+		}
+		return status
+	}
+	return c
+}
+
+// inputFields повертає JSON-назви полів вхідних даних, що задаються одним
+// значенням; поля вбудованих структур входять до списку.
+func inputFields(t reflect.Type) []string {
+	var names []string
+	for i := range t.NumField() {
+		f := t.Field(i)
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if f.Anonymous && ft.Kind() == reflect.Struct && name == "" {
+			names = append(names, inputFields(ft)...)
+			continue
+		}
+		if !f.IsExported() || name == "-" {
+			continue
+		}
+		switch ft.Kind() {
+		case reflect.Float64, reflect.Int, reflect.Int64, reflect.String, reflect.Bool:
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// table — стовпці CSV, що не є полями вхідних даних (номер зразка, дата
+// тощо), для виводу поруч із результатами.
+type table struct {
+	Header []string
+	Rows   [][]string
+}
+
+// readInputs читає зі стандартного входу об'єкт JSON, масив об'єктів або
+// таблицю CSV чи XLSX. Для таблиці повертає також її додаткові стовпці.
+func readInputs[In any](r io.Reader) (inputs []In, errs []error, labels *table, err error) {
+	data, err := io.ReadAll(io.LimitReader(r, batch.MaxSize))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, nil, nil, errors.New("немає вхідних даних: задайте прапорці полів або передайте JSON чи CSV на стандартний вхід")
+	}
+
+	switch trimmed[0] {
+	case '{':
+		var in In
+		err := strictUnmarshal(trimmed, &in)
+		return []In{in}, []error{err}, nil, nil
+	case '[':
+		var raw []json.RawMessage
+		if err := json.Unmarshal(trimmed, &raw); err != nil {
+			return nil, nil, nil, err
+		}
+		inputs, errs = make([]In, len(raw)), make([]error, len(raw))
+		for i, item := range raw {
+			errs[i] = strictUnmarshal(item, &inputs[i])
+		}
+		return inputs, errs, nil, nil
+	}
+
+	t, err := batch.Read(data)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	labels = &table{Rows: make([][]string, len(t.Rows))}
+	known := make([]bool, len(t.Header))
+	for i, name := range t.Header {
+		known[i] = batch.IsField[In](name)
+		if !known[i] {
+			labels.Header = append(labels.Header, name)
+		}
+	}
+	inputs, errs = make([]In, len(t.Rows)), make([]error, len(t.Rows))
+	for i, row := range t.Rows {
+		fields := map[string]string{}
+		labels.Rows[i] = make([]string, 0, len(labels.Header))
+		for j := range t.Header {
+			v := ""
+			if j < len(row) {
+				v = row[j]
+			}
+			if known[j] {
+				fields[t.Header[j]] = v
+			} else {
+				labels.Rows[i] = append(labels.Rows[i], v)
+			}
+		}
+		inputs[i], errs[i] = batch.Decode[In](fields)
+	}
+	return inputs, errs, labels, nil
+}
+
+func strictUnmarshal(data []byte, v any) error {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.DisallowUnknownFields()
+	return d.Decode(v)
+}
+
+// writeTable виводить один результат стовпцями "поле значення", а кілька —
+// таблицею з рядком на кожен набір вхідних даних.
+func writeTable(w io.Writer, outcomes []outcome, labels *table) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if len(outcomes) == 1 && labels == nil {
+		if outcomes[0].Error != "" {
+			fmt.Fprintf(tw, "error\t%s\n", outcomes[0].Error)
+			return tw.Flush()
+		}
+		fields, err := batch.Flatten(outcomes[0].Result)
+		if err != nil {
+			return err
+		}
+		for _, f := range fields {
+			fmt.Fprintf(tw, "%s\t%s\n", f.Name, f.Value)
+		}
+		return tw.Flush()
+	}
+
+	var labelColumns []string
+	if labels != nil {
+		labelColumns = labels.Header
+	}
+	var columns []string
+	index := map[string]int{}
+	results := make([][]batch.Field, len(outcomes))
+	for i, o := range outcomes {
+		if o.Error != "" {
+			continue
+		}
+		fields, err := batch.Flatten(o.Result)
+		if err != nil {
+			return err
+		}
+		results[i] = fields
+		for _, f := range fields {
+			if _, ok := index[f.Name]; !ok {
+				index[f.Name] = len(columns)
+				columns = append(columns, f.Name)
+			}
+		}
+	}
+
+	fmt.Fprintln(tw, strings.Join(append(append(append([]string{"#"}, labelColumns...), columns...), "error"), "\t"))
+	for i, o := range outcomes {
+		rec := make([]string, 1+len(labelColumns)+len(columns)+1)
+		rec[0] = fmt.Sprint(i + 1)
+		if labels != nil {
+			copy(rec[1:], labels.Rows[i])
+		}
+		for _, f := range results[i] {
+			rec[1+len(labelColumns)+index[f.Name]] = f.Value
+		}
+		rec[len(rec)-1] = o.Error
+		fmt.Fprintln(tw, strings.Join(rec, "\t"))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInputFields(t *testing.T) {
+	type base struct {
+		Pc float64 `json:"pc"`
+	}
+	type input struct {
+		base
+		Days   int       `json:"days,omitempty"`
+		Seed   *int64    `json:"seed,omitempty"`
+		Name   string    `json:"name"`
+		Values []float64 `json:"values"`
+		Skip   bool      `json:"-"`
+	}
+	got := inputFields(reflect.TypeOf(input{}))
+	want := []string{"pc", "days", "seed", "name"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("inputFields = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,73 @@
+// Команда calc виконує розрахунки калькуляторів з командного рядка.
+//
+//	calc <команда> [прапорці]
+//
+// Вхідні дані задаються прапорцями з назвами полів JSON API (calc fuel
+// -hp 3.8 -cp 62.1 ...) або, якщо прапорців полів немає, читаються зі
+// стандартного входу: об'єкт JSON, масив об'єктів або таблиця CSV зі
+// зразком у кожному рядку. Результат виводиться таблицею або JSON
+// (-format json).
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"Go_tutor/cable"
+	"Go_tutor/emissions"
+	"Go_tutor/fuel"
+	"Go_tutor/load"
+	"Go_tutor/losses"
+	"Go_tutor/reliability"
+	"Go_tutor/shortcircuit"
+	"Go_tutor/solar"
+)
+
+// Команди калькуляторів
+var commands = map[string]command{
+	"fuel":         newCommand("склад і теплота згоряння твердого палива", fuel.Calculate),
+	"mazut":        newCommand("перерахунок складу мазуту на робочу масу", fuel.CalculateMazut),
+	"bases":        newCommand("перерахунок складу палива між масами", fuel.CalculateBases),
+	"emissions":    withCatalog(newCommand("валові викиди від спалювання палива", emissions.Calculate)),
+	"blend":        withCatalog(newCommand("викиди від суміші палив (лише JSON)", emissions.CalculateBlend)),
+	"solar":        newCommand("прибуток сонячної електростанції", solar.Calculate),
+	"hourly":       newCommand("погодинний прибуток сонячної електростанції", solar.CalculateHourly),
+	"simulate":     newCommand("моделювання Монте-Карло доходу сонячної електростанції", solar.Simulate),
+	"cable":        newCommand("вибір перерізу кабелю", cable.Calculate),
+	"shortcircuit": newCommand("струми короткого замикання", shortcircuit.Calculate),
+	"reliability":  newCommand("надійність електропостачання", reliability.Calculate),
+	"losses":       newCommand("втрати від перерв електропостачання", losses.Calculate),
+	"load":         newCommand("розрахунок електричних навантажень", load.Calculate),
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		if os.Args[1] != "help" && os.Args[1] != "-h" && os.Args[1] != "-help" {
+			fmt.Fprintf(os.Stderr, "calc: невідома команда %q\n", os.Args[1])
+		}
+		usage()
+		os.Exit(2)
+	}
+	os.Exit(cmd.run(os.Args[1], os.Args[2:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func usage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(os.Stderr, "Використання: calc <команда> [прапорці] [< вхідні дані]")
+	fmt.Fprintln(os.Stderr, "\nКоманди:")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-13s %s\n", name, commands[name].summary)
+	}
+	fmt.Fprintln(os.Stderr, "\nПрапорці полів команди: calc <команда> -h")
+}
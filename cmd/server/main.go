@@ -0,0 +1,147 @@
+// Команда server запускає всі калькулятори лабораторних робіт в одному
+// HTTP-сервері, кожен під своїм префіксом.
+package main
+
+import (
+	"flag"
+	"html/template"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"Go_tutor/api"
+	"Go_tutor/emissions"
+	firstlab "Go_tutor/first_lab"
+	fivelab "Go_tutor/five_lab"
+	fourthlab "Go_tutor/fourth_lab"
+	"Go_tutor/i18n"
+	"Go_tutor/ledger"
+	secondlab "Go_tutor/second_lab"
+	sixlab "Go_tutor/six_lab"
+	"Go_tutor/tax"
+	thirdlab "Go_tutor/third_lab"
+)
+
+// Калькулятор, змонтований під власним префіксом. Title — ключ перекладу.
+type calculator struct {
+	Path    string
+	Title   string
+	Handler http.Handler
+}
+
+func calculators() []calculator {
+	emission := http.NewServeMux()
+	emission.HandleFunc("/", secondlab.FormHandler)
+	emission.HandleFunc("/catalog", secondlab.CatalogHandler)
+	emission.HandleFunc("/ledger", secondlab.LedgerHandler)
+	emission.HandleFunc("/permits", secondlab.PermitsHandler)
+	emission.HandleFunc("/tax", secondlab.TaxHandler)
+
+	solar := http.NewServeMux()
+	solar.HandleFunc("/", thirdlab.HomeHandler)
+	solar.HandleFunc("/fit", thirdlab.FitHandler)
+	solar.HandleFunc("/hourly", thirdlab.HourlyHandler)
+
+	reliability := http.NewServeMux()
+	reliability.HandleFunc("/", fivelab.IndexHandler)
+	reliability.HandleFunc("/calculate", fivelab.CalculateHandler)
+
+	load := http.NewServeMux()
+	load.HandleFunc("/", sixlab.IndexHandler)
+	load.HandleFunc("/calculate", sixlab.CalculateHandler)
+
+	return []calculator{
+		{"/fuel/composition/", "index.fuel", http.HandlerFunc(firstlab.FuelHandler)},
+		{"/fuel/mazut/", "index.mazut", http.HandlerFunc(firstlab.MazutHandler)},
+		{"/fuel/bases/", "index.bases", http.HandlerFunc(firstlab.BasesHandler)},
+		{"/emissions/", "index.emissions", emission},
+		{"/solar/", "index.solar", solar},
+		{"/cable/", "index.cable", http.HandlerFunc(fourthlab.CableHandler)},
+		{"/short-circuit/", "index.short", http.HandlerFunc(fourthlab.ShortCircuitHandler)},
+		{"/reliability/", "index.reliability", reliability},
+		{"/losses/", "index.losses", http.HandlerFunc(fivelab.LossesHandler)},
+		{"/load/", "index.load", load},
+	}
+}
+
+func newServer() http.Handler {
+	mux := http.NewServeMux()
+	calcs := calculators()
+	for _, c := range calcs {
+		mux.Handle(c.Path, http.StripPrefix(strings.TrimSuffix(c.Path, "/"), c.Handler))
+	}
+	mux.Handle(api.Prefix, api.Handler())
+	mux.HandleFunc("/{$}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		data := struct {
+			i18n.Localizer
+			Calculators []calculator
+		}{i18n.FromRequest(w, r), calcs}
+		if err := indexTmpl.Execute(w, data); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+	return mux
+}
+
+var indexTmpl = template.Must(template.New("index").Parse(`
+<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+	<meta charset="UTF-8">
+	<title>{{.T "index.title"}}</title>
+	<style>
+		body { font-family: Arial, sans-serif; background-color: #f4f4f4; text-align: center; padding: 20px; }
+		.container { background: white; padding: 20px; max-width: 500px; margin: auto; border-radius: 5px; box-shadow: 0px 0px 10px rgba(0,0,0,0.1); text-align: left; }
+		li { margin: 8px 0; }
+	</style>
+</head>
+<body>
+	{{.Switcher}}
+	<div class="container">
+		<h1>{{.T "index.title"}}</h1>
+		<ul>
+		{{range .Calculators}}
+			<li><a href="{{.Path}}">{{$.T .Title}}</a> <code>{{.Path}}</code></li>
+		{{end}}
+		</ul>
+		<p>JSON API: <code>POST /api/v1/&lt;calculator&gt;</code></p>
+	</div>
+</body>
+</html>
+`))
+
+func main() {
+	addr := flag.String("addr", ":8080", "адреса HTTP-сервера")
+	catalog := flag.String("catalog", "fuels.json", `файл довідника палива (.json або .csv); -catalog="" — лише вбудований довідник у пам'яті, зміни не зберігаються`)
+	ledgerPath := flag.String("ledger", "ledger.json", `файл журналу викидів; -ledger="" — журнал лише в пам'яті, записи й дозволи не зберігаються`)
+	taxPath := flag.String("tax", "tax_rates.json", `файл ставок екологічного податку; -tax="" — лише вбудовані ставки в пам'яті, зміни не зберігаються`)
+	flag.Parse()
+
+	if *catalog != "" {
+		if err := emissions.Default.Open(*catalog); err != nil {
+			log.Fatalf("довідник палива: %v", err)
+		}
+		go emissions.Default.Watch(2 * time.Second)
+	} else {
+		log.Print("УВАГА: довідник палива лише в пам'яті, зміни буде втрачено після перезапуску")
+	}
+	if *taxPath != "" {
+		if err := tax.Default.Open(*taxPath); err != nil {
+			log.Fatalf("ставки податку: %v", err)
+		}
+	} else {
+		log.Print("УВАГА: ставки податку лише в пам'яті, зміни буде втрачено після перезапуску")
+	}
+	if *ledgerPath != "" {
+		if err := ledger.Default.Open(*ledgerPath); err != nil {
+			log.Fatalf("журнал викидів: %v", err)
+		}
+	} else {
+		log.Print("УВАГА: журнал викидів і дозволи лише в пам'яті, їх буде втрачено після перезапуску")
+	}
+
+	log.Printf("Сервер запущено на %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, newServer()))
+}
@@ -0,0 +1,116 @@
+package emissions
+
+import (
+	"fmt"
+
+	"Go_tutor/validate"
+)
+
+// BlendInput — вхідні дані для спільного спалювання кількох палив на
+// одному енергоблоці.
+type BlendInput struct {
+	Lines []Line `json:"lines"`
+	Equipment
+}
+
+// LineResult — викиди від одного палива суміші.
+// Одиниця кількості вказана в Result.Unit.
+type LineResult struct {
+	Fuel     string  `json:"fuel"`
+	Quantity float64 `json:"quantity"`
+	Result
+}
+
+// Totals — сумарні викиди суміші. Показники емісії зважені за енергією палив.
+type Totals struct {
+	Energy       float64  `json:"energy"` // Енергія палива (ГДж)
+	Particulates Emission `json:"particulates"`
+	SO2          Emission `json:"so2"`
+	NOx          Emission `json:"nox"`
+	CO2          Emission `json:"co2"`
+}
+
+// BlendResult — викиди кожного палива та їх сума.
+type BlendResult struct {
+	Lines []LineResult `json:"lines"`
+	Total Totals       `json:"total"`
+}
+
+// LineField повертає назву поля рядка суміші, під якою повідомляються
+// помилки, наприклад "lines.0.fuel".
+func LineField(i int, field string) string {
+	return fmt.Sprintf("lines.%d.%s", i, field)
+}
+
+// Validate перевіряє кожен рядок суміші та параметри обладнання.
+func (in BlendInput) Validate() error {
+	errs := validate.Errors{}
+	if len(in.Lines) == 0 {
+		errs.Add("lines", "at least one fuel is required")
+	}
+	for i, line := range in.Lines {
+		line.validate(errs, func(field string) string { return LineField(i, field) })
+	}
+	in.Equipment.validate(errs)
+	return errs.Err()
+}
+
+// CalculateBlend розраховує викиди для кожного палива суміші та сумарні
+// викиди енергоблока.
+func CalculateBlend(in BlendInput) (BlendResult, error) {
+	if err := in.Validate(); err != nil {
+		return BlendResult{}, err
+	}
+
+	res := BlendResult{Lines: make([]LineResult, 0, len(in.Lines))}
+	for _, line := range in.Lines {
+		r, err := Calculate(Input{line, in.Equipment})
+		if err != nil {
+			return BlendResult{}, err
+		}
+		res.Lines = append(res.Lines, LineResult{line.Fuel, line.Quantity, r})
+		res.Total.Add(r)
+	}
+	return res, nil
+}
+
+// Add додає викиди одного палива до суми й перераховує середньозважені
+// показники емісії.
+func (t *Totals) Add(r Result) {
+	t.Merge(Totals{
+		Energy:       r.Energy,
+		Particulates: Emission{Total: r.TotalEmission},
+		SO2:          r.SO2,
+		NOx:          r.NOx,
+		CO2:          r.CO2,
+	})
+}
+
+// Merge додає до суми інші сумарні викиди.
+func (t *Totals) Merge(o Totals) {
+	t.Energy += o.Energy
+	t.Particulates.Total += o.Particulates.Total
+	t.SO2.Total += o.SO2.Total
+	t.NOx.Total += o.NOx.Total
+	t.CO2.Total += o.CO2.Total
+	if t.Energy > 0 {
+		for _, e := range []*Emission{&t.Particulates, &t.SO2, &t.NOx, &t.CO2} {
+			e.Factor = e.Total * 1000000 / t.Energy
+		}
+	}
+}
+
+// Pollutant повертає сумарний викид речовини p.
+func (t Totals) Pollutant(p string) Emission {
+	switch p {
+	case PollutantParticulates:
+		return t.Particulates
+	case PollutantSO2:
+		return t.SO2
+	case PollutantNOx:
+		return t.NOx
+	case PollutantCO2:
+		return t.CO2
+	}
+	return Emission{}
+}
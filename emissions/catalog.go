@@ -0,0 +1,337 @@
+package emissions
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"Go_tutor/fsutil"
+	"Go_tutor/validate"
+)
+
+// Типи палива в довіднику
+const (
+	TypeCoal  = "coal"
+	TypeMazut = "mazut"
+	TypeGas   = "gas"
+)
+
+// Grade — марка палива в довіднику.
+type Grade struct {
+	Name    string  `json:"name"`
+	Type    string  `json:"type"`    // coal, mazut або gas
+	A       float64 `json:"a"`       // Зольність (%)
+	W       float64 `json:"w"`       // Вологість (%)
+	S       float64 `json:"s"`       // Сірка на робочу масу (%)
+	C       float64 `json:"c"`       // Вуглець на робочу масу (%)
+	Q       float64 `json:"q"`       // Нижча теплота згоряння (МДж/кг, для газу МДж/нм³)
+	Density float64 `json:"density"` // Щільність (кг/м³, для газу кг/нм³)
+}
+
+// Validate перевіряє марку палива перед додаванням до довідника.
+func (g Grade) Validate() error {
+	errs := validate.Errors{}
+	if strings.TrimSpace(g.Name) == "" {
+		errs.Add("name", "value is required")
+	}
+	switch g.Type {
+	case TypeCoal, TypeMazut:
+		errs.Percent("a", g.A)
+		errs.Percent("w", g.W)
+		if g.W+g.A >= 100 {
+			errs.Add("w", "moisture and ash together must be less than 100%%")
+		}
+		errs.NonNegative("density", g.Density)
+		if g.W+g.A+g.S+g.C > 100 {
+			errs.Add("c", "components must not exceed 100%%")
+		}
+	case TypeGas:
+		errs.Positive("density", g.Density)
+	default:
+		errs.Add("type", "must be coal, mazut or gas")
+	}
+	errs.Percent("s", g.S)
+	errs.Percent("c", g.C)
+	errs.Positive("q", g.Q)
+	return errs.Err()
+}
+
+// Fuel повертає характеристики твердого палива або мазуту.
+func (g Grade) Fuel() Fuel {
+	return Fuel{A: g.A, W: g.W, S: g.S, C: g.C, Q: g.Q, Type: g.Type}
+}
+
+// Gas повертає характеристики газу.
+func (g Grade) Gas() Gas {
+	return Gas{Q: g.Q, Ro: g.Density, S: g.S, C: g.C}
+}
+
+// Вбудований довідник, що використовується без файлу
+var builtinGrades = []Grade{
+	// Вугілля
+	{Name: "Антрацитовий штиб АШ", Type: TypeCoal, A: 5.0, W: 3.0, S: 1.7, C: 85.6, Q: 33.24},
+	{Name: "Пісне вугілля ТР", Type: TypeCoal, A: 12.0, W: 6.0, S: 2.0, C: 73.8, Q: 34.29},
+	{Name: "Донецьке газове ГР", Type: TypeCoal, A: 25.20, W: 10.0, S: 2.1, C: 51.8, Q: 31.98},
+	{Name: "Донецьке довгополуменеве ДР", Type: TypeCoal, A: 35.0, W: 15.0, S: 1.75, C: 38.5, Q: 30.56},
+	{Name: "Львівсько-волинське (ЛВ) ГР", Type: TypeCoal, A: 18.0, W: 10.0, S: 2.2, C: 57.6, Q: 31.69},
+	{Name: "Олександрійське буре БІР", Type: TypeCoal, A: 45.0, W: 25.0, S: 1.2, C: 20.4, Q: 26.96},
+	// Мазут
+	{Name: "Високосірчастий 40", Type: TypeMazut, A: 0.15, W: 2.00, S: 2.5, C: 84.5, Q: 40.40},
+	{Name: "Високосірчастий 100", Type: TypeMazut, A: 0.15, W: 2.00, S: 2.7, C: 84.2, Q: 40.03},
+	{Name: "Високосірчастий 200", Type: TypeMazut, A: 0.30, W: 1.00, S: 3.0, C: 84.0, Q: 39.77},
+	{Name: "Малосірчастий 40", Type: TypeMazut, A: 0.15, W: 2.00, S: 0.5, C: 86.2, Q: 41.24},
+	{Name: "Малосірчастий 100", Type: TypeMazut, A: 0.15, W: 2.00, S: 0.5, C: 86.0, Q: 40.82},
+	// Газ
+	{Name: "Уренгой—Ужгород", Type: TypeGas, C: 74.5, Q: 33.08, Density: 0.723},
+	{Name: "Середня Азія—Центр", Type: TypeGas, C: 73.8, Q: 34.21, Density: 0.764},
+}
+
+// Default — довідник, з яким працюють Calculate і Known.
+var Default = NewCatalog(builtinGrades)
+
+// Catalog — довідник марок палива, безпечний для одночасного використання.
+// Після Open зміни зберігаються у файл, а Watch підхоплює зміни файлу.
+type Catalog struct {
+	mu      sync.RWMutex
+	grades  []Grade
+	path    string
+	modTime time.Time
+}
+
+// NewCatalog створює довідник у пам'яті з копії grades.
+func NewCatalog(grades []Grade) *Catalog {
+	return &Catalog{grades: append([]Grade(nil), grades...)}
+}
+
+// Get повертає марку палива за назвою.
+func (c *Catalog) Get(name string) (Grade, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	i := c.index(name)
+	if i < 0 {
+		return Grade{}, false
+	}
+	return c.grades[i], true
+}
+
+// List повертає копію всіх марок у порядку довідника.
+func (c *Catalog) List() []Grade {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]Grade(nil), c.grades...)
+}
+
+// Put додає нову марку або замінює наявну з тією ж назвою. Повертає true,
+// якщо марку додано.
+func (c *Catalog) Put(g Grade) (bool, error) {
+	g.Name = strings.TrimSpace(g.Name)
+	if err := g.Validate(); err != nil {
+		return false, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	grades := append([]Grade(nil), c.grades...)
+	i := c.index(g.Name)
+	if i < 0 {
+		grades = append(grades, g)
+	} else {
+		grades[i] = g
+	}
+	if err := c.save(grades); err != nil {
+		return false, err
+	}
+	c.grades = grades
+	return i < 0, nil
+}
+
+// Delete видаляє марку з довідника.
+func (c *Catalog) Delete(name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	i := c.index(name)
+	if i < 0 {
+		return ErrUnknownFuel
+	}
+	grades := append(append([]Grade(nil), c.grades[:i]...), c.grades[i+1:]...)
+	if err := c.save(grades); err != nil {
+		return err
+	}
+	c.grades = grades
+	return nil
+}
+
+// Open прив'язує довідник до файлу. Наявний файл завантажується, а якщо
+// його немає, у нього записується поточний вміст довідника. Формат
+// визначається розширенням: .csv — CSV, інакше JSON.
+func (c *Catalog) Open(path string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.path = path
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return c.save(c.grades)
+	}
+	_, err := c.load()
+	return err
+}
+
+// Reload перечитує файл, якщо він змінився після останнього читання чи
+// запису. Повертає true, якщо довідник оновлено. Файл з помилками не
+// замінює поточний довідник.
+func (c *Catalog) Reload() (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.path == "" {
+		return false, nil
+	}
+	return c.load()
+}
+
+// Watch кожні interval перевіряє файл довідника й перезавантажує його
+// після змін. Помилки записуються в журнал.
+func (c *Catalog) Watch(interval time.Duration) {
+	for range time.Tick(interval) {
+		if reloaded, err := c.Reload(); err != nil {
+			log.Printf("довідник палива: %v", err)
+		} else if reloaded {
+			log.Printf("довідник палива перезавантажено з %s", c.path)
+		}
+	}
+}
+
+func (c *Catalog) index(name string) int {
+	for i, g := range c.grades {
+		if g.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// load читає файл, якщо час його зміни відрізняється від запам'ятованого.
+func (c *Catalog) load() (bool, error) {
+	info, err := os.Stat(c.path)
+	if err != nil {
+		return false, err
+	}
+	if info.ModTime().Equal(c.modTime) {
+		return false, nil
+	}
+	f, err := os.Open(c.path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	var grades []Grade
+	if isCSV(c.path) {
+		grades, err = readCSV(f)
+	} else {
+		err = json.NewDecoder(f).Decode(&grades)
+	}
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", c.path, err)
+	}
+	seen := make(map[string]bool, len(grades))
+	for i, g := range grades {
+		if err := g.Validate(); err != nil {
+			return false, fmt.Errorf("%s: fuel %d (%q): %w", c.path, i+1, g.Name, err)
+		}
+		if seen[g.Name] {
+			return false, fmt.Errorf("%s: duplicate fuel %q", c.path, g.Name)
+		}
+		seen[g.Name] = true
+	}
+	c.grades = grades
+	c.modTime = info.ModTime()
+	return true, nil
+}
+
+// save атомарно записує grades у файл довідника, якщо його задано.
+func (c *Catalog) save(grades []Grade) error {
+	if c.path == "" {
+		return nil
+	}
+	err := fsutil.WriteFileAtomic(c.path, func(w io.Writer) error {
+		if isCSV(c.path) {
+			return writeCSV(w, grades)
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(grades)
+	})
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(c.path)
+	if err != nil {
+		return err
+	}
+	c.modTime = info.ModTime()
+	return nil
+}
+
+func isCSV(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".csv")
+}
+
+// Стовпці CSV-файлу довідника. Під час читання порядок стовпців береться
+// із заголовка, а відсутні числові стовпці вважаються нульовими.
+var csvHeader = []string{"name", "type", "a", "w", "s", "c", "q", "density"}
+
+func readCSV(r io.Reader) ([]Grade, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	columns := make(map[string]int, len(records[0]))
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"name", "type"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing column %q", name)
+		}
+	}
+
+	var grades []Grade
+	for line, rec := range records[1:] {
+		g := Grade{Name: strings.TrimSpace(rec[columns["name"]]), Type: strings.TrimSpace(rec[columns["type"]])}
+		for name, dst := range map[string]*float64{"a": &g.A, "w": &g.W, "s": &g.S, "c": &g.C, "q": &g.Q, "density": &g.Density} {
+			i, ok := columns[name]
+			if !ok || strings.TrimSpace(rec[i]) == "" {
+				continue
+			}
+			if *dst, err = strconv.ParseFloat(strings.TrimSpace(rec[i]), 64); err != nil {
+				return nil, fmt.Errorf("line %d: column %s: %w", line+2, name, err)
+			}
+		}
+		grades = append(grades, g)
+	}
+	return grades, nil
+}
+
+func writeCSV(w io.Writer, grades []Grade) error {
+	cw := csv.NewWriter(w)
+	cw.Write(csvHeader)
+	for _, g := range grades {
+		row := []string{g.Name, g.Type}
+		for _, v := range []float64{g.A, g.W, g.S, g.C, g.Q, g.Density} {
+			row = append(row, strconv.FormatFloat(v, 'f', -1, 64))
+		}
+		cw.Write(row)
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,226 @@
+// Пакет emissions розраховує валові викиди твердих частинок, оксидів сірки,
+// оксидів азоту та CO2 при спалюванні вугілля, мазуту та природного газу.
+package emissions
+
+import (
+	"errors"
+
+	"Go_tutor/validate"
+)
+
+// ErrUnknownFuel повертається для палива, якого немає в довіднику.
+var ErrUnknownFuel = errors.New("unknown fuel type")
+
+// Вугілля або мазут
+type Fuel struct {
+	A    float64 // Зольність (%)
+	W    float64 // Вологість (%)
+	S    float64 // Сірка (%)
+	C    float64 // Вуглець (%)
+	Q    float64 // Нижча теплота згоряння (МДж/кг)
+	Type string  // Тип палива (вугілля чи мазут)
+}
+
+// Природний газ
+type Gas struct {
+	Q  float64 // Нижча теплота згоряння (МДж/нм³)
+	Ro float64 // Щільність (кг/нм³)
+	S  float64 // Масова частка сірки (%)
+	C  float64 // Масова частка вуглецю (%)
+}
+
+// Частка сірки, що зв'язується золою в топці
+var sulfurBinding = map[string]float64{
+	TypeCoal:  0.1,
+	TypeMazut: 0.02,
+	TypeGas:   0,
+}
+
+// Молярні співвідношення SO2/S та CO2/C
+const (
+	so2PerSulfur = 64.06 / 32.06
+	co2PerCarbon = 44.01 / 12.011
+)
+
+// Line — паливо та його кількість.
+type Line struct {
+	Fuel     string  `json:"fuel"`           // Назва палива з довідника
+	Quantity float64 `json:"quantity"`       // Кількість палива
+	Unit     string  `json:"unit,omitempty"` // Одиниця кількості, за замовчуванням залежить від палива
+}
+
+// Вхідні дані
+type Input struct {
+	Line
+	Equipment
+}
+
+// Emission — показник емісії та валовий викид однієї забруднюючої речовини.
+type Emission struct {
+	Factor float64 `json:"factor"` // Показник емісії (г/ГДж)
+	Total  float64 `json:"total"`  // Валовий викид (т)
+}
+
+// Забруднюючі речовини
+const (
+	PollutantParticulates = "particulates"
+	PollutantSO2          = "so2"
+	PollutantNOx          = "nox"
+	PollutantCO2          = "co2"
+)
+
+// Pollutants повертає забруднюючі речовини в порядку для таблиць.
+func Pollutants() []string {
+	return []string{PollutantParticulates, PollutantSO2, PollutantNOx, PollutantCO2}
+}
+
+// Результат розрахунку викидів. EmissionFactor і TotalEmission стосуються
+// твердих частинок.
+type Result struct {
+	EmissionFactor float64  `json:"emission_factor"` // Показник емісії (г/ГДж)
+	TotalEmission  float64  `json:"total_emission"`  // Валовий викид (т)
+	SO2            Emission `json:"so2"`             // Оксиди сірки в перерахунку на SO2
+	NOx            Emission `json:"nox"`             // Оксиди азоту в перерахунку на NO2
+	CO2            Emission `json:"co2"`             // Діоксид вуглецю
+	Energy         float64  `json:"energy"`          // Енергія спаленого палива (ГДж)
+	Unit           string   `json:"unit"`            // Застосована одиниця кількості палива
+
+	Conditions Conditions `json:"conditions"` // Застосовані параметри котла й очищення
+}
+
+// Pollutant повертає викид речовини p.
+func (r Result) Pollutant(p string) Emission {
+	switch p {
+	case PollutantParticulates:
+		return Emission{Factor: r.EmissionFactor, Total: r.TotalEmission}
+	case PollutantSO2:
+		return r.SO2
+	case PollutantNOx:
+		return r.NOx
+	case PollutantCO2:
+		return r.CO2
+	}
+	return Emission{}
+}
+
+// Validate перевіряє, що паливо є в довіднику, а кількість додатна.
+func (in Input) Validate() error {
+	errs := validate.Errors{}
+	in.Line.validate(errs, func(field string) string { return field })
+	in.Equipment.validate(errs)
+	return errs.Err()
+}
+
+// validate перевіряє рядок; field перетворює назву поля на ключ помилки.
+func (l Line) validate(errs validate.Errors, field func(string) string) {
+	grade, ok := Default.Get(l.Fuel)
+	if !ok {
+		errs.Add(field("fuel"), ErrUnknownFuel.Error())
+	}
+	errs.Positive(field("quantity"), l.Quantity)
+	if l.Unit != "" && !knownUnit(l.Unit) {
+		errs.Add(field("unit"), ErrUnknownUnit.Error())
+	} else if ok {
+		if _, err := grade.Native(l.Quantity, l.unit(grade.Type)); err != nil {
+			errs.Add(field("unit"), err.Error())
+		}
+	}
+}
+
+func (l Line) unit(fuelType string) string {
+	if l.Unit == "" {
+		return DefaultUnit(fuelType)
+	}
+	return l.Unit
+}
+
+// Known повідомляє, чи є паливо в довіднику Default.
+func Known(name string) bool {
+	_, ok := Default.Get(name)
+	return ok
+}
+
+// Calculate розраховує викиди для палива з довідника Default, будь то
+// тверде паливо, мазут чи газ.
+func Calculate(in Input) (Result, error) {
+	if err := in.Validate(); err != nil {
+		return Result{}, err
+	}
+	grade, ok := Default.Get(in.Fuel)
+	if !ok {
+		return Result{}, ErrUnknownFuel
+	}
+	unit := in.unit(grade.Type)
+	quantity, err := grade.Native(in.Quantity, unit)
+	if err != nil {
+		return Result{}, err
+	}
+
+	cond := in.Equipment.Conditions(grade.Type)
+	var res Result
+	if grade.Type == TypeGas {
+		res = GasEmission(grade.Gas(), quantity, cond)
+	} else {
+		res = FuelEmission(grade.Fuel(), quantity, cond)
+	}
+	res.Unit = unit
+	return res, nil
+}
+
+// FuelEmission розраховує викиди для вугілля або мазуту масою fuelMass (т).
+func FuelEmission(fuel Fuel, fuelMass float64, cond Conditions) Result {
+	qr := fuel.LHV()
+
+	res := Result{
+		EmissionFactor: particulateFactor(fuel.A, qr, cond),
+		SO2:            Emission{Factor: sulfurFactor(fuel.S, qr, fuel.Type, cond)},
+		NOx:            Emission{Factor: nitrogenFactors[cond.Burner][fuel.Type]},
+		CO2:            Emission{Factor: carbonFactor(fuel.C, qr)},
+		Conditions:     cond,
+	}
+	res.total(qr * fuelMass)
+	return res
+}
+
+// GasEmission розраховує викиди при спалюванні газу об'ємом gasVolume
+// (тис. нм³). Газ не містить золи, тому викид твердих частинок нульовий.
+func GasEmission(gas Gas, gasVolume float64, cond Conditions) Result {
+	// Теплота згоряння на одиницю маси, МДж/кг
+	qm := gas.Q / gas.Ro
+
+	res := Result{
+		SO2:        Emission{Factor: sulfurFactor(gas.S, qm, TypeGas, cond)},
+		NOx:        Emission{Factor: nitrogenFactors[cond.Burner][TypeGas]},
+		CO2:        Emission{Factor: carbonFactor(gas.C, qm)},
+		Conditions: cond,
+	}
+	res.total(gas.Q * gasVolume)
+	return res
+}
+
+// total розраховує валові викиди для кількості енергії палива energy (ГДж).
+func (r *Result) total(energy float64) {
+	r.Energy = energy
+	r.TotalEmission = 0.000001 * r.EmissionFactor * energy
+	for _, e := range []*Emission{&r.SO2, &r.NOx, &r.CO2} {
+		e.Total = 0.000001 * e.Factor * energy
+	}
+}
+
+// particulateFactor — показник емісії твердих частинок (г/ГДж) за
+// зольністю a (%) палива з теплотою згоряння q (МДж/кг).
+func particulateFactor(a, q float64, cond Conditions) float64 {
+	return (1000000 / q) * cond.FlyAsh * (a / (100 - cond.CombustibleInAsh)) * (1 - cond.Efficiency)
+}
+
+// sulfurFactor — показник емісії SO2 (г/ГДж) за вмістом сірки s (%)
+// у паливі з теплотою згоряння q (МДж/кг).
+func sulfurFactor(s, q float64, fuelType string, cond Conditions) float64 {
+	return (1000000 / q) * so2PerSulfur * (s / 100) * (1 - sulfurBinding[fuelType]) * (1 - cond.SO2Efficiency)
+}
+
+// carbonFactor — показник емісії CO2 (г/ГДж) за вмістом вуглецю c (%)
+// за умови повного окиснення.
+func carbonFactor(c, q float64) float64 {
+	return (1000000 / q) * co2PerCarbon * (c / 100)
+}
@@ -0,0 +1,168 @@
+package emissions
+
+import (
+	"errors"
+
+	"Go_tutor/validate"
+)
+
+// ErrUnknownBurner повертається для невідомого типу пальникового пристрою.
+var ErrUnknownBurner = errors.New("unknown burner type")
+
+// ErrUnknownBoiler повертається для невідомого типу котла.
+var ErrUnknownBoiler = errors.New("unknown boiler type")
+
+// ErrUnknownAbatement повертається для невідомого газоочисного обладнання.
+var ErrUnknownAbatement = errors.New("unknown abatement equipment")
+
+// Типи пальникових пристроїв
+const (
+	BurnerStandard = "standard" // Звичайні пальники
+	BurnerLowNOx   = "low_nox"  // Малотоксичні пальники
+	BurnerStaged   = "staged"   // Малотоксичні пальники зі ступеневим спалюванням
+)
+
+// Показники емісії NOx (г/ГДж) за типом пальників і палива
+var nitrogenFactors = map[string]map[string]float64{
+	BurnerStandard: {TypeCoal: 210, TypeMazut: 140, TypeGas: 90},
+	BurnerLowNOx:   {TypeCoal: 130, TypeMazut: 90, TypeGas: 50},
+	BurnerStaged:   {TypeCoal: 100, TypeMazut: 70, TypeGas: 35},
+}
+
+// Burners повертає типи пальників у порядку для списку вибору.
+func Burners() []string {
+	return []string{BurnerStandard, BurnerLowNOx, BurnerStaged}
+}
+
+// Типи котлів
+const (
+	BoilerChamberDry = "chamber_dry" // Камерна топка з твердим шлаковидаленням
+	BoilerChamberWet = "chamber_wet" // Камерна топка з рідким шлаковидаленням
+	BoilerGrate      = "grate"       // Шарова (колосникова) топка
+	BoilerFluidBed   = "fluid_bed"   // Топка з киплячим шаром
+	BoilerOilGas     = "oil_gas"     // Газомазутний котел
+)
+
+// Boiler — параметри топки, від яких залежить винесення золи.
+type Boiler struct {
+	FlyAsh           float64 // Частка золи, що виноситься з газами (a_вин)
+	CombustibleInAsh float64 // Вміст горючих речовин у винесенні (Г_вин, %)
+}
+
+var boilers = map[string]Boiler{
+	BoilerChamberDry: {FlyAsh: 0.95, CombustibleInAsh: 1.5},
+	BoilerChamberWet: {FlyAsh: 0.8, CombustibleInAsh: 1.5},
+	BoilerGrate:      {FlyAsh: 0.2, CombustibleInAsh: 10},
+	BoilerFluidBed:   {FlyAsh: 0.6, CombustibleInAsh: 3},
+	BoilerOilGas:     {FlyAsh: 1, CombustibleInAsh: 0},
+}
+
+// Котел за замовчуванням для кожного типу палива
+var defaultBoilers = map[string]string{
+	TypeCoal:  BoilerChamberWet,
+	TypeMazut: BoilerOilGas,
+	TypeGas:   BoilerOilGas,
+}
+
+// Boilers повертає типи котлів у порядку для списку вибору.
+func Boilers() []string {
+	return []string{BoilerChamberDry, BoilerChamberWet, BoilerGrate, BoilerFluidBed, BoilerOilGas}
+}
+
+// Газоочисне обладнання
+const (
+	AbatementESP      = "esp"          // Електрофільтр
+	AbatementBag      = "bag_filter"   // Рукавний фільтр
+	AbatementScrubber = "wet_scrubber" // Мокрий скрубер
+	AbatementNone     = "none"         // Без очищення
+)
+
+// Abatement — ефективність газоочисного обладнання.
+type Abatement struct {
+	Efficiency    float64 // Ступінь уловлювання твердих частинок (η_зу)
+	SO2Efficiency float64 // Ступінь уловлювання SO2
+}
+
+var abatements = map[string]Abatement{
+	AbatementESP:      {Efficiency: 0.985},
+	AbatementBag:      {Efficiency: 0.995},
+	AbatementScrubber: {Efficiency: 0.95, SO2Efficiency: 0.1},
+	AbatementNone:     {},
+}
+
+// Abatements повертає типи газоочисного обладнання для списку вибору.
+func Abatements() []string {
+	return []string{AbatementESP, AbatementBag, AbatementScrubber, AbatementNone}
+}
+
+// Equipment — обладнання енергоблока. Порожні поля замінюються типовими
+// значеннями, а числові параметри, якщо задані, перекривають пресети
+// котла й газоочистки.
+type Equipment struct {
+	Burner           string   `json:"burner,omitempty"`             // Тип пальників, за замовчуванням standard
+	Boiler           string   `json:"boiler,omitempty"`             // Тип котла, за замовчуванням залежить від палива
+	Abatement        string   `json:"abatement,omitempty"`          // Газоочистка, за замовчуванням esp
+	FlyAsh           *float64 `json:"fly_ash,omitempty"`            // Частка золи, що виноситься з газами
+	CombustibleInAsh *float64 `json:"combustible_in_ash,omitempty"` // Вміст горючих у винесенні (%)
+	Efficiency       *float64 `json:"efficiency,omitempty"`         // Ступінь уловлювання твердих частинок
+}
+
+// Conditions — параметри, фактично застосовані в розрахунку.
+type Conditions struct {
+	Burner           string  `json:"burner"`
+	Boiler           string  `json:"boiler"`
+	Abatement        string  `json:"abatement"`
+	FlyAsh           float64 `json:"fly_ash"`
+	CombustibleInAsh float64 `json:"combustible_in_ash"`
+	Efficiency       float64 `json:"efficiency"`
+	SO2Efficiency    float64 `json:"so2_efficiency"`
+}
+
+func (e Equipment) validate(errs validate.Errors) {
+	if _, ok := nitrogenFactors[e.Burner]; e.Burner != "" && !ok {
+		errs.Add("burner", ErrUnknownBurner.Error())
+	}
+	if _, ok := boilers[e.Boiler]; e.Boiler != "" && !ok {
+		errs.Add("boiler", ErrUnknownBoiler.Error())
+	}
+	if _, ok := abatements[e.Abatement]; e.Abatement != "" && !ok {
+		errs.Add("abatement", ErrUnknownAbatement.Error())
+	}
+	if e.FlyAsh != nil {
+		errs.Range("fly_ash", *e.FlyAsh, 0, 1)
+	}
+	if e.CombustibleInAsh != nil {
+		errs.Range("combustible_in_ash", *e.CombustibleInAsh, 0, 99)
+	}
+	if e.Efficiency != nil {
+		errs.Range("efficiency", *e.Efficiency, 0, 1)
+	}
+}
+
+// Conditions підставляє пресети для палива типу fuelType.
+func (e Equipment) Conditions(fuelType string) Conditions {
+	c := Conditions{Burner: e.Burner, Boiler: e.Boiler, Abatement: e.Abatement}
+	if c.Burner == "" {
+		c.Burner = BurnerStandard
+	}
+	if c.Boiler == "" {
+		c.Boiler = defaultBoilers[fuelType]
+	}
+	if c.Abatement == "" {
+		c.Abatement = AbatementESP
+	}
+
+	boiler, abatement := boilers[c.Boiler], abatements[c.Abatement]
+	c.FlyAsh, c.CombustibleInAsh = boiler.FlyAsh, boiler.CombustibleInAsh
+	c.Efficiency, c.SO2Efficiency = abatement.Efficiency, abatement.SO2Efficiency
+	if e.FlyAsh != nil {
+		c.FlyAsh = *e.FlyAsh
+	}
+	if e.CombustibleInAsh != nil {
+		c.CombustibleInAsh = *e.CombustibleInAsh
+	}
+	if e.Efficiency != nil {
+		c.Efficiency = *e.Efficiency
+	}
+	return c
+}
@@ -0,0 +1,76 @@
+package emissions
+
+import "errors"
+
+// Одиниці кількості палива
+const (
+	UnitTonnes     = "t"           // Тонни
+	UnitThousandM3 = "thousand_m3" // Тисячі м³ (для газу — нормальних)
+	UnitGJ         = "gj"          // ГДж енергії палива
+)
+
+// ErrUnknownUnit повертається для невідомої одиниці кількості.
+var ErrUnknownUnit = errors.New("unknown quantity unit")
+
+// ErrNoDensity повертається, коли об'єм не можна перерахувати в масу,
+// бо в довіднику не задано щільність палива.
+var ErrNoDensity = errors.New("fuel density is not set, volume cannot be converted")
+
+// Units повертає одиниці кількості для списку вибору.
+func Units() []string {
+	return []string{UnitTonnes, UnitThousandM3, UnitGJ}
+}
+
+// DefaultUnit — одиниця за замовчуванням: тис. нм³ для газу, тонни для
+// решти палив.
+func DefaultUnit(fuelType string) string {
+	if fuelType == TypeGas {
+		return UnitThousandM3
+	}
+	return UnitTonnes
+}
+
+func knownUnit(unit string) bool {
+	return unit == UnitTonnes || unit == UnitThousandM3 || unit == UnitGJ
+}
+
+// LHV повертає нижчу теплоту згоряння робочої маси (МДж/кг).
+func (f Fuel) LHV() float64 {
+	if f.Type == TypeCoal {
+		return f.Q * (1 - ((f.W + f.A) / 100))
+	}
+	return f.Q
+}
+
+// Native перераховує кількість палива в одиниці, з якими працюють
+// FuelEmission (тонни) та GasEmission (тис. нм³).
+func (g Grade) Native(quantity float64, unit string) (float64, error) {
+	if g.Type == TypeGas {
+		switch unit {
+		case UnitThousandM3:
+			return quantity, nil
+		case UnitTonnes:
+			// т / (кг/нм³) = тис. нм³
+			return quantity / g.Density, nil
+		case UnitGJ:
+			// ГДж / (МДж/нм³) = тис. нм³
+			return quantity / g.Q, nil
+		}
+		return 0, ErrUnknownUnit
+	}
+
+	switch unit {
+	case UnitTonnes:
+		return quantity, nil
+	case UnitThousandM3:
+		if g.Density <= 0 {
+			return 0, ErrNoDensity
+		}
+		// тис. м³ · кг/м³ = т
+		return quantity * g.Density, nil
+	case UnitGJ:
+		// ГДж / (МДж/кг) = т
+		return quantity / g.Fuel().LHV(), nil
+	}
+	return 0, ErrUnknownUnit
+}
@@ -0,0 +1,137 @@
+package firstlab
+
+import (
+	"html/template"
+	"net/http"
+
+	"Go_tutor/fuel"
+	"Go_tutor/i18n"
+	"Go_tutor/number"
+	"Go_tutor/validate"
+)
+
+type basesPage struct {
+	i18n.Localizer
+	Form       *validate.Form
+	Num        number.Formatter
+	Bases      []string
+	Components []string
+	Result     *fuel.BasisResult
+}
+
+var basesSpecs = number.Specs{
+	"percent": {Precision: 2, Unit: "unit.percent"},
+	"q":       {Precision: 2, Unit: "unit.mj_per_kg"},
+	"k":       {Precision: 4},
+}
+
+// BasesHandler перераховує склад палива із заданої маси на всі інші.
+func BasesHandler(w http.ResponseWriter, r *http.Request) {
+	loc := i18n.FromRequest(w, r)
+	data := basesPage{
+		Localizer:  loc,
+		Num:        loc.Formatter(r, basesSpecs),
+		Bases:      fuel.Bases(),
+		Components: []string{"h", "c", "s", "n", "o", "a", "w"},
+	}
+
+	if r.Method == http.MethodPost && r.FormValue("clear") == "" {
+		form := loc.Form(r)
+		in := fuel.BasisInput{
+			Basis: form.Value("basis"),
+			H:     form.Float("h"),
+			C:     form.Float("c"),
+			S:     form.Float("s"),
+			N:     form.Float("n"),
+			O:     form.Float("o"),
+			W:     form.Float("w"),
+			A:     form.Float("a"),
+		}
+		if sk := form.OptionalFloat("sk"); sk != nil {
+			in.SK = *sk
+		}
+
+		data.Form = form
+		if form.Valid() {
+			if result, err := fuel.CalculateBases(in); err != nil {
+				form.Fail(err)
+			} else {
+				data.Result = &result
+			}
+		}
+	}
+	basesTmpl.Execute(w, data)
+}
+
+var basesTmpl = template.Must(template.New("bases").Parse(`
+<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+	<meta charset="UTF-8">
+	<title>{{.T "bases.title"}}</title>
+	<style>
+		body { font-family: Arial, sans-serif; background-color: #f4f4f4; text-align: center; padding: 20px; }
+		form { background: white; padding: 20px; max-width: 340px; margin: auto; border-radius: 5px; box-shadow: 0px 0px 10px rgba(0,0,0,0.1); }
+		input, select { width: 100%; padding: 8px; margin: 5px 0; border: 1px solid #ccc; border-radius: 4px; }
+		input[type="submit"] { background-color: #28a745; color: white; border: none; padding: 10px; cursor: pointer; }
+		input[type="submit"]:hover { background-color: #218838; }
+		button { background-color: #dc3545; color: white; border: none; padding: 10px; cursor: pointer; width: 100%; margin-top: 10px; }
+		button:hover { background-color: #c82333; }
+		.error { color: #dc3545; font-size: 0.9em; }
+		.results { background: white; padding: 20px; max-width: 700px; margin: 20px auto; border-radius: 5px; box-shadow: 0px 0px 10px rgba(0,0,0,0.1); }
+		table { border-collapse: collapse; margin: 10px auto; }
+		th, td { border: 1px solid #ccc; padding: 6px 10px; }
+	</style>
+</head>
+<body>
+	{{.Switcher}}
+	<h1>{{.T "bases.heading"}}</h1>
+	<form method="post">
+		<label>{{.T "bases.source"}}:
+			<select name="basis">
+				{{range .Bases}}<option value="{{.}}"{{if eq . ($.Form.Value "basis")}} selected{{end}}>{{$.T (print "bases." .)}}</option>
+				{{end}}
+			</select>
+		</label>{{with .Form.Error "basis"}}<span class="error">{{.}}</span>{{end}}<br>
+		<label>{{.T "element.h"}} (H): <input type="text" name="h" value="{{.Form.Value "h"}}"></label>{{with .Form.Error "h"}}<span class="error">{{.}}</span>{{end}}<br>
+		<label>{{.T "element.c"}} (C): <input type="text" name="c" value="{{.Form.Value "c"}}"></label>{{with .Form.Error "c"}}<span class="error">{{.}}</span>{{end}}<br>
+		<label>{{.T "element.s"}} (S): <input type="text" name="s" value="{{.Form.Value "s"}}"></label>{{with .Form.Error "s"}}<span class="error">{{.}}</span>{{end}}<br>
+		<label>{{.T "element.n"}} (N): <input type="text" name="n" value="{{.Form.Value "n"}}"></label>{{with .Form.Error "n"}}<span class="error">{{.}}</span>{{end}}<br>
+		<label>{{.T "element.o"}} (O): <input type="text" name="o" value="{{.Form.Value "o"}}"></label>{{with .Form.Error "o"}}<span class="error">{{.}}</span>{{end}}<br>
+		<label>{{.T "bases.w"}} (W<sup>r</sup>): <input type="text" name="w" value="{{.Form.Value "w"}}"></label>{{with .Form.Error "w"}}<span class="error">{{.}}</span>{{end}}<br>
+		<label>{{.T "bases.a"}} (A<sup>d</sup>): <input type="text" name="a" value="{{.Form.Value "a"}}"></label>{{with .Form.Error "a"}}<span class="error">{{.}}</span>{{end}}<br>
+		<label>{{.T "bases.sk"}} (S<sub>k</sub><sup>d</sup>): <input type="text" name="sk" placeholder="0" value="{{.Form.Value "sk"}}"></label>{{with .Form.Error "sk"}}<span class="error">{{.}}</span>{{end}}<br>
+		{{with .Form.Error "composition"}}<p class="error">{{.}}</p>{{end}}
+		{{with .Form.Error ""}}<p class="error">{{.}}</p>{{end}}
+		<input type="submit" value="{{.T "common.calculate"}}">
+		<button type="submit" name="clear" value="true">{{.T "common.clear"}}</button>
+	</form>
+	{{with .Result}}
+	<div class="results">
+		<h2>{{$.T "common.results"}}:</h2>
+		<table>
+			<tr><th></th>{{range $.Bases}}<th>{{$.T (print "bases." .)}}</th>{{end}}</tr>
+			{{range $c := $.Components}}
+			<tr>
+				<td>{{$.T (print "element." $c)}}</td>
+				{{range $.Bases}}{{$comp := index $.Result.Compositions .}}<td>{{$.Num.Format "percent" ($comp.Get $c)}}</td>{{end}}
+			</tr>
+			{{end}}
+			<tr><td>{{$.T "bases.hhv"}}</td>{{range $.Bases}}<td>{{$.Num.Format "q" (index $.Result.Compositions .).HHV}}</td>{{end}}</tr>
+			<tr><td>{{$.T "fuel.lhv"}}</td>{{range $.Bases}}<td>{{$.Num.Format "q" (index $.Result.Compositions .).LHV}}</td>{{end}}</tr>
+		</table>
+		<h3>{{$.T "bases.matrix"}}</h3>
+		<table>
+			<tr><th>{{$.T "bases.from_to"}}</th>{{range $.Bases}}<th>{{$.T (print "bases." .)}}</th>{{end}}</tr>
+			{{range $from := $.Bases}}
+			<tr>
+				<th>{{$.T (print "bases." $from)}}</th>
+				{{range $to := $.Bases}}<td>{{$.Num.Format "k" (index (index $.Result.Coefficients $from) $to)}}</td>{{end}}
+			</tr>
+			{{end}}
+		</table>
+	</div>
+	{{end}}
+</body>
+</html>
+`))
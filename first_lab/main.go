@@ -1,68 +1,87 @@
-package main
+package firstlab
 
 import (
-	"fmt"
 	"html/template"
 	"net/http"
-	"strconv"
+
+	"Go_tutor/fuel"
+	"Go_tutor/i18n"
+	"Go_tutor/number"
+	"Go_tutor/validate"
 )
 
-type FuelData struct {
-	HP, CP, SP, NP, OP, WP, AP                                float64
-	KRS, KRG, HC, CC, SC, NC, OC, AC, HG, CG, SG, NG, OG, QrH float64
-	ShowResults                                               bool
+type fuelPage struct {
+	i18n.Localizer
+	Form   *validate.Form
+	Num    number.Formatter
+	Result *fuel.Result
 }
 
-func calculateFuelData(fd *FuelData) {
-	fd.KRS = 100 / (100 - fd.WP)
-	fd.KRG = 100 / (100 - fd.WP - fd.AP)
-
-	fd.HC = fd.HP * fd.KRS
-	fd.CC = fd.CP * fd.KRS
-	fd.SC = fd.SP * fd.KRS
-	fd.NC = fd.NP * fd.KRS
-	fd.OC = fd.OP * fd.KRS
-	fd.AC = fd.AP * fd.KRS
-
-	fd.HG = fd.HP * fd.KRG
-	fd.CG = fd.CP * fd.KRG
-	fd.SG = fd.SP * fd.KRG
-	fd.NG = fd.NP * fd.KRG
-	fd.OG = fd.OP * fd.KRG
-
-	fd.QrH = 339*fd.CP + 1030*fd.HP - 108.8*(fd.OP-fd.SP) - 25*fd.WP
-	fd.ShowResults = true
+var fuelSpecs = number.Specs{
+	"krs":   {Precision: 4},
+	"krg":   {Precision: 4},
+	"hc":    {Precision: 2, Unit: "unit.percent"},
+	"cc":    {Precision: 2, Unit: "unit.percent"},
+	"sc":    {Precision: 2, Unit: "unit.percent"},
+	"nc":    {Precision: 2, Unit: "unit.percent"},
+	"oc":    {Precision: 2, Unit: "unit.percent"},
+	"ac":    {Precision: 2, Unit: "unit.percent"},
+	"hg":    {Precision: 2, Unit: "unit.percent"},
+	"cg":    {Precision: 2, Unit: "unit.percent"},
+	"sg":    {Precision: 2, Unit: "unit.percent"},
+	"ng":    {Precision: 2, Unit: "unit.percent"},
+	"og":    {Precision: 2, Unit: "unit.percent"},
+	"qrh":   {Precision: 2, Unit: "unit.mj_per_kg"},
+	"coef":  {Precision: 1},
+	"value": {Precision: 2},
+	"term":  {Precision: 3, Unit: "unit.mj_per_kg"},
+	"alpha": {Precision: 2},
+	"gas":   {Precision: 3, Unit: "unit.m3_per_kg"},
 }
 
-func fuelHandler(w http.ResponseWriter, r *http.Request) {
+func FuelHandler(w http.ResponseWriter, r *http.Request) {
+	loc := i18n.FromRequest(w, r)
 	if r.Method != http.MethodPost {
-		tmpl.Execute(w, nil)
+		tmpl.Execute(w, fuelPage{Localizer: loc})
 		return
 	}
 
 	if r.FormValue("clear") != "" {
-		tmpl.Execute(w, nil)
+		tmpl.Execute(w, fuelPage{Localizer: loc})
 		return
 	}
 
-	fd := FuelData{}
-	fd.HP, _ = strconv.ParseFloat(r.FormValue("HP"), 64)
-	fd.CP, _ = strconv.ParseFloat(r.FormValue("CP"), 64)
-	fd.SP, _ = strconv.ParseFloat(r.FormValue("SP"), 64)
-	fd.NP, _ = strconv.ParseFloat(r.FormValue("NP"), 64)
-	fd.OP, _ = strconv.ParseFloat(r.FormValue("OP"), 64)
-	fd.WP, _ = strconv.ParseFloat(r.FormValue("WP"), 64)
-	fd.AP, _ = strconv.ParseFloat(r.FormValue("AP"), 64)
+	form := loc.Form(r)
+	in := fuel.Input{
+		HP: form.Float("hp"),
+		CP: form.Float("cp"),
+		SP: form.Float("sp"),
+		NP: form.Float("np"),
+		OP: form.Float("op"),
+		WP: form.Float("wp"),
+		AP: form.Float("ap"),
+	}
+	if alpha := form.OptionalFloat("alpha"); alpha != nil {
+		in.Alpha = *alpha
+	}
 
-	calculateFuelData(&fd)
-	tmpl.Execute(w, fd)
+	data := fuelPage{Localizer: loc, Form: form, Num: loc.Formatter(r, fuelSpecs)}
+	if form.Valid() {
+		if result, err := fuel.Calculate(in); err != nil {
+			form.Fail(err)
+		} else {
+			data.Result = &result
+		}
+	}
+	tmpl.Execute(w, data)
 }
 
 var tmpl = template.Must(template.New("fuel").Parse(`
 <!DOCTYPE html>
-<html>
+<html lang="{{.Lang}}">
 <head>
-	<title>Калькулятор складу палива</title>
+	<meta charset="UTF-8">
+	<title>{{.T "fuel.title"}}</title>
 	<style>
 		body { font-family: Arial, sans-serif; background-color: #f4f4f4; text-align: center; padding: 20px; }
 		form { background: white; padding: 20px; max-width: 300px; margin: auto; border-radius: 5px; box-shadow: 0px 0px 10px rgba(0,0,0,0.1); }
@@ -71,38 +90,77 @@ var tmpl = template.Must(template.New("fuel").Parse(`
 		input[type="submit"]:hover { background-color: #218838; }
 		button { background-color: #dc3545; color: white; border: none; padding: 10px; cursor: pointer; width: 100%; margin-top: 10px; }
 		button:hover { background-color: #c82333; }
-		.results { background: white; padding: 20px; max-width: 400px; margin: 20px auto; border-radius: 5px; box-shadow: 0px 0px 10px rgba(0,0,0,0.1); }
+		.error { color: #dc3545; font-size: 0.9em; }
+		table { border-collapse: collapse; margin: 10px auto; }
+		th, td { border: 1px solid #ccc; padding: 4px 8px; }
+		.results { background: white; padding: 20px; max-width: 500px; margin: 20px auto; border-radius: 5px; box-shadow: 0px 0px 10px rgba(0,0,0,0.1); }
 	</style>
 </head>
 <body>
-	<h1>Перше завдання</h1>
+	{{.Switcher}}
+	<h1>{{.T "fuel.heading"}}</h1>
 	<form method="post">
-		<label>Водень (HP): <input type="text" name="HP"></label><br>
-		<label>Вуглець (CP): <input type="text" name="CP"></label><br>
-		<label>Сірка (SP): <input type="text" name="SP"></label><br>
-		<label>Азот (NP): <input type="text" name="NP"></label><br>
-		<label>Кисень (OP): <input type="text" name="OP"></label><br>
-		<label>Волога (WP): <input type="text" name="WP"></label><br>
-		<label>Зола (AP): <input type="text" name="AP"></label><br>
-		<input type="submit" value="Розрахувати">
-		<button type="submit" name="clear" value="true">Очистити результати</button>
+		<label>{{.T "element.h"}} (HP): <input type="text" name="hp" value="{{.Form.Value "hp"}}"></label>{{with .Form.Error "hp"}}<span class="error">{{.}}</span>{{end}}<br>
+		<label>{{.T "element.c"}} (CP): <input type="text" name="cp" value="{{.Form.Value "cp"}}"></label>{{with .Form.Error "cp"}}<span class="error">{{.}}</span>{{end}}<br>
+		<label>{{.T "element.s"}} (SP): <input type="text" name="sp" value="{{.Form.Value "sp"}}"></label>{{with .Form.Error "sp"}}<span class="error">{{.}}</span>{{end}}<br>
+		<label>{{.T "element.n"}} (NP): <input type="text" name="np" value="{{.Form.Value "np"}}"></label>{{with .Form.Error "np"}}<span class="error">{{.}}</span>{{end}}<br>
+		<label>{{.T "element.o"}} (OP): <input type="text" name="op" value="{{.Form.Value "op"}}"></label>{{with .Form.Error "op"}}<span class="error">{{.}}</span>{{end}}<br>
+		<label>{{.T "element.w"}} (WP): <input type="text" name="wp" value="{{.Form.Value "wp"}}"></label>{{with .Form.Error "wp"}}<span class="error">{{.}}</span>{{end}}<br>
+		<label>{{.T "element.a"}} (AP): <input type="text" name="ap" value="{{.Form.Value "ap"}}"></label>{{with .Form.Error "ap"}}<span class="error">{{.}}</span>{{end}}<br>
+		<label>{{.T "fuel.alpha"}} (α): <input type="text" name="alpha" placeholder="1,2" value="{{.Form.Value "alpha"}}"></label>{{with .Form.Error "alpha"}}<span class="error">{{.}}</span>{{end}}<br>
+		{{with .Form.Error "composition"}}<p class="error">{{.}}</p>{{end}}
+		{{with .Form.Error ""}}<p class="error">{{.}}</p>{{end}}
+		<input type="submit" value="{{.T "common.calculate"}}">
+		<button type="submit" name="clear" value="true">{{.T "common.clear"}}</button>
 	</form>
-	{{if .ShowResults}}
+
+	<h2>{{.T "batch.heading"}}</h2>
+	<form method="post" action="/api/v1/fuel/batch" enctype="multipart/form-data">
+		<p>{{.T "batch.hint" "hp, cp, sp, np, op, wp, ap, alpha"}}</p>
+		<input type="file" name="file" accept=".csv,.xlsx">
+		<input type="submit" value="{{.T "batch.submit"}}">
+	</form>
+	{{with .Result}}
 		<div class="results">
-			<h2>Результати:</h2>
-			<p>Коефіцієнт сухої маси (KRS): {{.KRS}}</p>
-			<p>Коефіцієнт горючої маси (KRG): {{.KRG}}</p>
-			<p>Сухий склад: Водень (HC): {{.HC}}, Вуглець (CC): {{.CC}}, Сірка (SC): {{.SC}}, Азот (NC): {{.NC}}, Кисень (OC): {{.OC}}, Зола (AC): {{.AC}}</p>
-			<p>Горючий склад: Водень (HG): {{.HG}}, Вуглець (CG): {{.CG}}, Сірка (SG): {{.SG}}, Азот (NG): {{.NG}}, Кисень (OG): {{.OG}}</p>
-			<p>Нижча теплота згоряння (QrH): {{.QrH}} МДж/кг</p>
+			<h2>{{$.T "common.results"}}:</h2>
+			<p>{{$.T "fuel.krs"}} (KRS): {{$.Num.Format "krs" .KRS}}</p>
+			<p>{{$.T "fuel.krg"}} (KRG): {{$.Num.Format "krg" .KRG}}</p>
+			<p>{{$.T "fuel.dry"}}: {{$.T "element.h"}} (HC): {{$.Num.Format "hc" .HC}}, {{$.T "element.c"}} (CC): {{$.Num.Format "cc" .CC}}, {{$.T "element.s"}} (SC): {{$.Num.Format "sc" .SC}}, {{$.T "element.n"}} (NC): {{$.Num.Format "nc" .NC}}, {{$.T "element.o"}} (OC): {{$.Num.Format "oc" .OC}}, {{$.T "element.a"}} (AC): {{$.Num.Format "ac" .AC}}</p>
+			<p>{{$.T "fuel.combustible"}}: {{$.T "element.h"}} (HG): {{$.Num.Format "hg" .HG}}, {{$.T "element.c"}} (CG): {{$.Num.Format "cg" .CG}}, {{$.T "element.s"}} (SG): {{$.Num.Format "sg" .SG}}, {{$.T "element.n"}} (NG): {{$.Num.Format "ng" .NG}}, {{$.T "element.o"}} (OG): {{$.Num.Format "og" .OG}}</p>
+			<p>{{$.T "fuel.lhv"}} (QrH): {{$.Num.Format "qrh" .QrH}}</p>
+			<p>{{$.T "fuel.lhv_dry"}} (QdH): {{$.Num.Format "qrh" .QdH}}</p>
+			<p>{{$.T "fuel.lhv_daf"}} (QdafH): {{$.Num.Format "qrh" .QdafH}}</p>
+			<p>{{$.T "fuel.hhv"}} (QrV): {{$.Num.Format "qrh" .QrV}}</p>
+
+			<h3>{{$.T "fuel.breakdown"}}</h3>
+			<p>QrH = 339·C + 1030·H − 108,8·(O − S) − 25·W, {{$.T "fuel.kj_note"}}</p>
+			<table>
+				<tr><th>{{$.T "fuel.term"}}</th><th>{{$.T "fuel.coefficient"}}</th><th>{{$.T "fuel.content"}}</th><th>{{$.T "fuel.contribution"}}</th></tr>
+				{{range .Terms}}
+				<tr><td>{{.Symbol}}</td><td>{{$.Num.Format "coef" .Coefficient}}</td><td>{{$.Num.Format "value" .Value}}</td><td>{{$.Num.Format "term" .Contribution}}</td></tr>
+				{{end}}
+				<tr><th colspan="3">QrH</th><th>{{$.Num.Format "term" .QrH}}</th></tr>
+			</table>
+			<p>QrV = QrH + 0,025·(9·H + W) = {{$.Num.Format "qrh" .QrV}}</p>
+			<p>QdH = (QrH + 0,025·W)·KRS = {{$.Num.Format "qrh" .QdH}}</p>
+			<p>QdafH = (QrH + 0,025·W)·KRG = {{$.Num.Format "qrh" .QdafH}}</p>
+
+			{{with .Combustion}}
+			<h3>{{$.T "fuel.combustion"}} (α = {{$.Num.Format "alpha" .Alpha}})</h3>
+			<table>
+				<tr><td>{{$.T "fuel.v0"}} (V⁰)</td><td>{{$.Num.Format "gas" .V0}}</td></tr>
+				<tr><td>{{$.T "fuel.air"}} (αV⁰)</td><td>{{$.Num.Format "gas" .Air}}</td></tr>
+				<tr><td>CO₂</td><td>{{$.Num.Format "gas" .CO2}}</td></tr>
+				<tr><td>SO₂</td><td>{{$.Num.Format "gas" .SO2}}</td></tr>
+				<tr><td>N₂</td><td>{{$.Num.Format "gas" .N2}}</td></tr>
+				<tr><td>H₂O</td><td>{{$.Num.Format "gas" .H2O}}</td></tr>
+				<tr><td>O₂</td><td>{{$.Num.Format "gas" .O2}}</td></tr>
+				<tr><th>{{$.T "fuel.flue_dry"}}</th><th>{{$.Num.Format "gas" .Dry}}</th></tr>
+				<tr><th>{{$.T "fuel.flue_total"}}</th><th>{{$.Num.Format "gas" .Total}}</th></tr>
+			</table>
+			{{end}}
 		</div>
 	{{end}}
 </body>
 </html>
 `))
-
-func main() {
-	http.HandleFunc("/", fuelHandler)
-	fmt.Println("Сервер запущено на :8080")
-	http.ListenAndServe(":8080", nil)
-}
@@ -1,43 +1,61 @@
-package main
+package firstlab
 
 import (
 	"html/template"
 	"net/http"
-	"strconv"
+
+	"Go_tutor/fuel"
+	"Go_tutor/i18n"
+	"Go_tutor/number"
+	"Go_tutor/validate"
 )
 
-type InputData struct {
-	H, C, S, Q, O, W, A, V float64
-	Results                *ResultData
+type mazutPage struct {
+	i18n.Localizer
+	Form    *validate.Form
+	Num     number.Formatter
+	Results *fuel.MazutResult
 }
 
-type ResultData struct {
-	CValc, HValc, OValc, SValc, AValc, Q_wm, VValc float64
+var mazutSpecs = number.Specs{
+	"c":        {Precision: 2, Unit: "unit.percent"},
+	"h":        {Precision: 2, Unit: "unit.percent"},
+	"o":        {Precision: 2, Unit: "unit.percent"},
+	"s":        {Precision: 2, Unit: "unit.percent"},
+	"a":        {Precision: 2, Unit: "unit.percent"},
+	"q":        {Precision: 2, Unit: "unit.mj_per_kg"},
+	"n":        {Precision: 2, Unit: "unit.percent"},
+	"v":        {Precision: 2, Unit: "unit.mg_per_kg"},
+	"residual": {Precision: 2, Unit: "unit.percent"},
 }
 
-func handler(w http.ResponseWriter, r *http.Request) {
-	data := InputData{}
+func MazutHandler(w http.ResponseWriter, r *http.Request) {
+	loc := i18n.FromRequest(w, r)
+	data := mazutPage{Localizer: loc, Num: loc.Formatter(r, mazutSpecs)}
 
-	if r.Method == http.MethodPost {
-		r.ParseForm()
-		data.H, _ = strconv.ParseFloat(r.FormValue("H"), 64)
-		data.C, _ = strconv.ParseFloat(r.FormValue("C"), 64)
-		data.S, _ = strconv.ParseFloat(r.FormValue("S"), 64)
-		data.Q, _ = strconv.ParseFloat(r.FormValue("Q"), 64)
-		data.O, _ = strconv.ParseFloat(r.FormValue("O"), 64)
-		data.W, _ = strconv.ParseFloat(r.FormValue("W"), 64)
-		data.A, _ = strconv.ParseFloat(r.FormValue("A"), 64)
-		data.V, _ = strconv.ParseFloat(r.FormValue("V"), 64)
+	if r.Method == http.MethodPost && r.FormValue("clear") == "" {
+		form := loc.Form(r)
+		in := fuel.MazutInput{
+			H: form.Float("h"),
+			C: form.Float("c"),
+			S: form.Float("s"),
+			Q: form.Float("q"),
+			O: form.Float("o"),
+			W: form.Float("w"),
+			A: form.Float("a"),
+			V: form.Float("v"),
+		}
+		// Азот часто не наводять у паспорті мазуту
+		if n := form.OptionalFloat("n"); n != nil {
+			in.N = *n
+		}
 
-		if data.H >= 0 && data.C >= 0 && data.S >= 0 && data.Q >= 0 && data.O >= 0 && data.W >= 0 && data.A >= 0 && data.V >= 0 {
-			data.Results = &ResultData{
-				Q_wm:  (data.Q * (100 - data.W - data.A)) / 100,
-				HValc: data.H * (100 - data.W - data.A) / 100,
-				CValc: data.C * (100 - data.W - data.A) / 100,
-				SValc: data.S * (100 - data.W - data.A) / 100,
-				OValc: data.O * (100 - data.W - data.A) / 100,
-				AValc: data.A * (100 - data.W) / 100,
-				VValc: data.V * (100 - data.W) / 100,
+		data.Form = form
+		if form.Valid() {
+			if result, err := fuel.CalculateMazut(in); err != nil {
+				form.Fail(err)
+			} else {
+				data.Results = &result
 			}
 		}
 	}
@@ -48,9 +66,10 @@ func handler(w http.ResponseWriter, r *http.Request) {
 
 const htmlTemplate = `
 <!DOCTYPE html>
-<html>
+<html lang="{{.Lang}}">
 <head>
-    <title>Fuel Composition Calculator</title>
+    <meta charset="UTF-8">
+    <title>{{.T "mazut.title"}}</title>
     <style>
         body { font-family: Arial, sans-serif; background-color: #f4f4f4; text-align: center; padding: 20px; }
         form { background: white; padding: 20px; max-width: 300px; margin: auto; border-radius: 5px; box-shadow: 0px 0px 10px rgba(0,0,0,0.1); }
@@ -59,40 +78,49 @@ const htmlTemplate = `
         input[type="submit"]:hover { background-color: #218838; }
         button { background-color: #dc3545; color: white; border: none; padding: 10px; cursor: pointer; width: 100%; margin-top: 10px; }
         button:hover { background-color: #c82333; }
+        .error { color: #dc3545; font-size: 0.9em; }
         .results { background: white; padding: 20px; max-width: 400px; margin: 20px auto; border-radius: 5px; box-shadow: 0px 0px 10px rgba(0,0,0,0.1); }
     </style>
 </head>
 <body>
-    <h1>Друге завдання</h1>
+    {{.Switcher}}
+    <h1>{{.T "mazut.heading"}}</h1>
     <form method="post">
-        <label>H: <input type="text" name="H"></label><br>
-        <label>C: <input type="text" name="C"></label><br>
-        <label>S: <input type="text" name="S"></label><br>
-        <label>Q: <input type="text" name="Q"></label><br>
-        <label>O: <input type="text" name="O"></label><br>
-        <label>W: <input type="text" name="W"></label><br>
-        <label>A: <input type="text" name="A"></label><br>
-        <label>V: <input type="text" name="V"></label><br>
-        <input type="submit" value="Calculate">
-        <button type="submit" name="clear" value="true">Clear Results</button>
+        <label>{{.T "element.h"}} (H): <input type="text" name="h" value="{{.Form.Value "h"}}"></label>{{with .Form.Error "h"}}<span class="error">{{.}}</span>{{end}}<br>
+        <label>{{.T "element.c"}} (C): <input type="text" name="c" value="{{.Form.Value "c"}}"></label>{{with .Form.Error "c"}}<span class="error">{{.}}</span>{{end}}<br>
+        <label>{{.T "element.s"}} (S): <input type="text" name="s" value="{{.Form.Value "s"}}"></label>{{with .Form.Error "s"}}<span class="error">{{.}}</span>{{end}}<br>
+        <label>{{.T "element.n"}} (N): <input type="text" name="n" placeholder="0" value="{{.Form.Value "n"}}"></label>{{with .Form.Error "n"}}<span class="error">{{.}}</span>{{end}}<br>
+        <label>{{.T "fuel.lhv"}} (Q): <input type="text" name="q" value="{{.Form.Value "q"}}"></label>{{with .Form.Error "q"}}<span class="error">{{.}}</span>{{end}}<br>
+        <label>{{.T "element.o"}} (O): <input type="text" name="o" value="{{.Form.Value "o"}}"></label>{{with .Form.Error "o"}}<span class="error">{{.}}</span>{{end}}<br>
+        <label>{{.T "element.w"}} (W): <input type="text" name="w" value="{{.Form.Value "w"}}"></label>{{with .Form.Error "w"}}<span class="error">{{.}}</span>{{end}}<br>
+        <label>{{.T "mazut.ash_dry"}} (A): <input type="text" name="a" value="{{.Form.Value "a"}}"></label>{{with .Form.Error "a"}}<span class="error">{{.}}</span>{{end}}<br>
+        <label>{{.T "mazut.vanadium_dry"}}, {{.T "unit.mg_per_kg"}} (V): <input type="text" name="v" value="{{.Form.Value "v"}}"></label>{{with .Form.Error "v"}}<span class="error">{{.}}</span>{{end}}<br>
+        {{with .Form.Error "composition"}}<p class="error">{{.}}</p>{{end}}
+        {{with .Form.Error ""}}<p class="error">{{.}}</p>{{end}}
+        <input type="submit" value="{{.T "common.calculate"}}">
+        <button type="submit" name="clear" value="true">{{.T "common.clear"}}</button>
+    </form>
+
+    <h2>{{.T "batch.heading"}}</h2>
+    <form method="post" action="/api/v1/mazut/batch" enctype="multipart/form-data">
+        <p>{{.T "batch.hint" "h, c, s, n, q, o, w, a, v"}}</p>
+        <input type="file" name="file" accept=".csv,.xlsx">
+        <input type="submit" value="{{.T "batch.submit"}}">
     </form>
     
     {{if .Results}}
     <div class="results">
-        <h2>Results:</h2>
-        <p>Вуглець: {{.Results.CValc}}</p>
-        <p>Водень: {{.Results.HValc}}</p>
-        <p>Кисень: {{.Results.OValc}}</p>
-        <p>Сірка: {{.Results.SValc}}</p>
-        <p>Зола: {{.Results.AValc}}</p>
-        <p>Нижча теплота згоряння: {{.Results.Q_wm}} МДж/кг</p>
-        <p>Вміст ванадію: {{.Results.VValc}} мг/кг</p>
+        <h2>{{.T "common.results"}}:</h2>
+        <p>{{.T "element.c"}}: {{$.Num.Format "c" .Results.C}}</p>
+        <p>{{.T "element.h"}}: {{$.Num.Format "h" .Results.H}}</p>
+        <p>{{.T "element.o"}}: {{$.Num.Format "o" .Results.O}}</p>
+        <p>{{.T "element.s"}}: {{$.Num.Format "s" .Results.S}}</p>
+        <p>{{.T "element.n"}}: {{$.Num.Format "n" .Results.N}}</p>
+        <p>{{.T "element.a"}}: {{$.Num.Format "a" .Results.A}}</p>
+        <p>{{.T "fuel.lhv"}}: {{$.Num.Format "q" .Results.Q}}</p>
+        <p>{{.T "mazut.vanadium"}}: {{$.Num.Format "v" .Results.V}}</p>
+        <p>{{.T "mazut.residual"}}: {{$.Num.Format "residual" .Results.Residual}}</p>
     </div>
     {{end}}
 </body>
 </html>`
-
-func main() {
-	http.HandleFunc("/", handler)
-	http.ListenAndServe(":8080", nil)
-}
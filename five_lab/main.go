@@ -1,111 +1,104 @@
-package main
+package fivelab
 
 import (
-	"fmt"
 	"html/template"
 	"net/http"
-	"strconv"
-)
-
-type Equipment struct {
-	Name  string
-	Omega float64 // Інтенсивність відмов (рік⁻¹)
-	Tfail float64 // Час відновлення (год)
-}
-
-type InputData struct {
-	Pv    float64
-	Kp    float64
-	T     float64
-	Equip Equipment
-}
-
-type Results struct {
-	Qo       float64
-	Tavg     float64
-	Ka       float64
-	M_energy float64
-}
-
-var equipmentData = map[string]Equipment{
-	"ПЛ-110 кВ": {"ПЛ-110 кВ", 0.007, 10},
-	"ПЛ-35 кВ":  {"ПЛ-35 кВ", 0.02, 8},
-	"Т-110 кВ":  {"Т-110 кВ", 0.015, 100},
-	"Т-35 кВ":   {"Т-35 кВ", 0.02, 80},
-}
 
-func calculateReliability(data InputData) Results {
-	Qo := data.Equip.Omega
-	Tavg := data.Equip.Tfail
-	Ka := (Qo * Tavg) / 8760
-	M_energy := data.Kp * data.Pv * data.T
-
-	return Results{Qo, Tavg, Ka, M_energy}
-}
+	"Go_tutor/i18n"
+	"Go_tutor/number"
+	"Go_tutor/reliability"
+	"Go_tutor/validate"
+)
 
 const htmlTemplate = `
 <!DOCTYPE html>
-<html lang="uk">
+<html lang="{{.Lang}}">
 <head>
     <meta charset="UTF-8">
     <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Розрахунок Надійності</title>
+    <title>{{.T "rel.title"}}</title>
     <script src="https://unpkg.com/htmx.org@1.9.4"></script>
+    <style>.error { color: red; }</style>
 </head>
 <body>
-    <h2>Введіть початкові дані</h2>
-    <form hx-post="/calculate" hx-target="#results" hx-swap="innerHTML">
-        <label>Навантаження (МВт): <input type="text" name="Pv"></label><br>
-        <label>Коефіцієнт використання: <input type="text" name="Kp"></label><br>
-        <label>Час роботи (год): <input type="text" name="T"></label><br>
-        <label>Тип обладнання:
+    {{.Switcher}}
+    <h2>{{.T "common.enter_data"}}</h2>
+    {{template "calculator" .}}
+</body>
+</html>
+{{define "calculator"}}
+<div id="calculator">
+    <form hx-post="calculate" hx-target="#calculator" hx-swap="outerHTML">
+        <label>{{.T "rel.pv"}}: <input type="text" name="pv" value="{{.Form.Value "pv"}}"></label>{{with .Form.Error "pv"}}<span class="error">{{.}}</span>{{end}}<br>
+        <label>{{.T "rel.kp"}}: <input type="text" name="kp" value="{{.Form.Value "kp"}}"></label>{{with .Form.Error "kp"}}<span class="error">{{.}}</span>{{end}}<br>
+        <label>{{.T "rel.t"}}: <input type="text" name="t" value="{{.Form.Value "t"}}"></label>{{with .Form.Error "t"}}<span class="error">{{.}}</span>{{end}}<br>
+        <label>{{.T "rel.equipment"}}:
             <select name="equipment">
-                <option value="ПЛ-110 кВ">ПЛ-110 кВ</option>
-                <option value="ПЛ-35 кВ">ПЛ-35 кВ</option>
-                <option value="Т-110 кВ">Т-110 кВ</option>
-                <option value="Т-35 кВ">Т-35 кВ</option>
+                {{range .Equipment}}<option value="{{.}}"{{if eq . ($.Form.Value "equipment")}} selected{{end}}>{{$.T .}}</option>
+                {{end}}
             </select>
-        </label><br>
-        <button type="submit">Розрахувати</button>
+        </label>{{with .Form.Error "equipment"}}<span class="error">{{.}}</span>{{end}}<br>
+        {{with .Form.Error ""}}<p class="error">{{.}}</p>{{end}}
+        <button type="submit">{{.T "common.calculate"}}</button>
     </form>
-    <div id="results"></div>
-</body>
-</html>`
+    <div id="results">
+    {{with .Results}}
+    <h3>{{$.T "common.results"}}:</h3>
+    <p>{{$.T "rel.qo"}}: {{ $.Num.Format "qo" .Qo }}</p>
+    <p>{{$.T "rel.tavg"}}: {{ $.Num.Format "tavg" .Tavg }}</p>
+    <p>{{$.T "rel.ka"}}: {{ $.Num.Format "ka" .Ka }}</p>
+    <p>{{$.T "rel.m_energy"}}: {{ $.Num.Format "m_energy" .MEnergy }}</p>
+    {{end}}
+    </div>
+</div>
+{{end}}`
+
+type pageData struct {
+	i18n.Localizer
+	Form      *validate.Form
+	Num       number.Formatter
+	Equipment []string
+	Results   *reliability.Result
+}
+
+var reliabilitySpecs = number.Specs{
+	"qo":       {Precision: 3, Unit: "unit.per_year"},
+	"tavg":     {Precision: 1, Unit: "unit.h"},
+	"ka":       {Precision: 6},
+	"m_energy": {Precision: 2, Unit: "unit.mwh"},
+}
 
-func indexHandler(w http.ResponseWriter, r *http.Request) {
-	tmpl := template.Must(template.New("index").Parse(htmlTemplate))
+var reliabilityTmpl = template.Must(template.New("index").Parse(htmlTemplate))
+
+func IndexHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	tmpl.Execute(w, nil)
+	loc := i18n.FromRequest(w, r)
+	reliabilityTmpl.Execute(w, pageData{Localizer: loc, Equipment: reliability.EquipmentNames()})
 }
 
-func calculateHandler(w http.ResponseWriter, r *http.Request) {
+func CalculateHandler(w http.ResponseWriter, r *http.Request) {
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
 		return
 	}
 
-	var input InputData
-	input.Pv, _ = strconv.ParseFloat(r.FormValue("Pv"), 64)
-	input.Kp, _ = strconv.ParseFloat(r.FormValue("Kp"), 64)
-	input.T, _ = strconv.ParseFloat(r.FormValue("T"), 64)
-	equipType := r.FormValue("equipment")
-	input.Equip = equipmentData[equipType]
+	loc := i18n.FromRequest(w, r)
+	form := loc.Form(r)
+	input := reliability.Input{
+		Pv:        form.Float("pv"),
+		Kp:        form.Float("kp"),
+		T:         form.Float("t"),
+		Equipment: form.String("equipment"),
+	}
 
-	results := calculateReliability(input)
-	tmpl := template.Must(template.New("results").Parse(`
-	<h3>Результати:</h3>
-	<p>Частота відмов: {{ .Qo }}</p>
-	<p>Середня тривалість відмови: {{ .Tavg }}</p>
-	<p>Коефіцієнт простою: {{ .Ka }}</p>
-	<p>Втрати енергії: {{ .M_energy }} МВт·год</p>
-	`))
+	data := pageData{Localizer: loc, Form: form, Num: loc.Formatter(r, reliabilitySpecs), Equipment: reliability.EquipmentNames()}
+	if form.Valid() {
+		if results, err := reliability.Calculate(input); err != nil {
+			form.Fail(err)
+		} else {
+			data.Results = &results
+		}
+	}
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	tmpl.Execute(w, results)
-}
-
-func main() {
-	http.HandleFunc("/", indexHandler)
-	http.HandleFunc("/calculate", calculateHandler)
-	fmt.Println("Сервер запущено на порту 8080...")
-	http.ListenAndServe(":8080", nil)
+	reliabilityTmpl.ExecuteTemplate(w, "calculator", data)
 }
@@ -1,81 +1,105 @@
-package main
+package fivelab
 
 import (
 	"html/template"
-	"log"
 	"net/http"
-	"strconv"
+	"net/url"
+
+	"Go_tutor/i18n"
+	"Go_tutor/losses"
+	"Go_tutor/number"
+	"Go_tutor/validate"
 )
 
 // HTML-шаблон для форми вводу та відображення результатів
 var tmpl = `
 <!DOCTYPE html>
-<html>
+<html lang="{{.Lang}}">
 <head>
-    <title>Розрахунок втрат електроенергії</title>
+    <meta charset="UTF-8">
+    <title>{{.T "losses.title"}}</title>
+    <style>.error { color: red; }</style>
 </head>
 <body>
-    <h1>Розрахунок втрат електроенергії</h1>
-    <form method="POST" action="/">
-        <label for="Pwt">Навантаження (МВт):</label>
-        <input type="text" id="Pwt" name="Pwt" required value="{{.Pwt}}"><br>
+    {{.Switcher}}
+    <h1>{{.T "losses.title"}}</h1>
+    <form method="POST" action="./">
+        <label for="pwt">{{.T "losses.pwt"}}:</label>
+        <input type="text" id="pwt" name="pwt" required value="{{.Form.Value "pwt"}}">
+        {{with .Form.Error "pwt"}}<span class="error">{{.}}</span>{{end}}<br>
 
-        <label for="Kp">Коефіцієнт використання:</label>
-        <input type="text" id="Kp" name="Kp" required value="{{.Kp}}"><br>
+        <label for="kp">{{.T "losses.kp"}}:</label>
+        <input type="text" id="kp" name="kp" required value="{{.Form.Value "kp"}}">
+        {{with .Form.Error "kp"}}<span class="error">{{.}}</span>{{end}}<br>
 
-        <label for="T">Час роботи в році (год):</label>
-        <input type="text" id="T" name="T" required value="{{.T}}"><br>
+        <label for="t">{{.T "losses.t"}}:</label>
+        <input type="text" id="t" name="t" required value="{{.Form.Value "t"}}">
+        {{with .Form.Error "t"}}<span class="error">{{.}}</span>{{end}}<br>
 
-        <label for="Wvt">Параметр W (Вт):</label>
-        <input type="text" id="Wvt" name="Wvt" required value="{{.Wvt}}"><br>
+        <label for="wvt">{{.T "losses.wvt"}}:</label>
+        <input type="text" id="wvt" name="wvt" required value="{{.Form.Value "wvt"}}">
+        {{with .Form.Error "wvt"}}<span class="error">{{.}}</span>{{end}}<br>
 
-        <input type="submit" value="Розрахувати">
+        {{with .Form.Error ""}}<p class="error">{{.}}</p>{{end}}
+        <input type="submit" value="{{.T "common.calculate"}}">
     </form>
 
-    {{if .Calculated}}
-    <h2>Результати</h2>
-    <p><strong>Втрати електроенергії автотрансформатора:</strong> {{.Mav}} кВт⋅год</p>
-    <p><strong>Математичне очікування втрат електропередачі:</strong> {{.MWvt}} кВт⋅год</p>
-    <p><strong>Загальні втрати електроенергії:</strong> {{.Mtotal}} кВт⋅год</p>
+    {{with .Result}}
+    <h2>{{$.T "common.results"}}</h2>
+    <p><strong>{{$.T "losses.mav"}}:</strong> {{$.Num.Format "mav" .Mav}}</p>
+    <p><strong>{{$.T "losses.m_wvt"}}:</strong> {{$.Num.Format "m_wvt" .MWvt}}</p>
+    <p><strong>{{$.T "losses.m_total"}}:</strong> {{$.Num.Format "m_total" .Mtotal}}</p>
     {{end}}
 </body>
 </html>
 `
 
-// Функція розрахунку втрат електроенергії
-func calculateLosses(Pwt, Kp float64, T int, Wvt float64) (float64, float64, float64) {
-	Mav := Kp * Pwt * float64(T)                     // Втрати автотрансформатора
-	MWvt := Kp * float64(T) * 4e-3 * 5.12e2 * Wvt    // Математичне очікування втрат
-	Mtotal := (23.6 * Mav) + (17.6 * MWvt) - 2682000 // Загальні втрати
-	return Mav, MWvt, Mtotal
+// Формат результатів
+var lossesSpecs = number.Specs{
+	"mav":     {Precision: 0, Unit: "unit.kwh"},
+	"m_wvt":   {Precision: 0, Unit: "unit.kwh"},
+	"m_total": {Precision: 0, Unit: "unit.kwh"},
 }
 
 // Обробник HTTP-запитів
-func handler(w http.ResponseWriter, r *http.Request) {
+func LossesHandler(w http.ResponseWriter, r *http.Request) {
 	// Початкові значення
+	loc := i18n.FromRequest(w, r)
 	data := struct {
-		Pwt, Kp, Wvt      float64
-		T                 int
-		Mav, MWvt, Mtotal float64
-		Calculated        bool
+		i18n.Localizer
+		Form   *validate.Form
+		Num    number.Formatter
+		Result *losses.Result
 	}{
-		Pwt: 23.6,
-		Kp:  0.7,
-		T:   51200,
-		Wvt: 6451,
+		Localizer: loc,
+		Num:       loc.Formatter(r, lossesSpecs),
+		Form: validate.Prefilled(url.Values{
+			"pwt": {"23.6"},
+			"kp":  {"0.7"},
+			"t":   {"51200"},
+			"wvt": {"6451"},
+		}),
 	}
 
 	if r.Method == http.MethodPost {
 		// Зчитуємо введені користувачем дані
-		Pwt, _ := strconv.ParseFloat(r.FormValue("Pwt"), 64)
-		Kp, _ := strconv.ParseFloat(r.FormValue("Kp"), 64)
-		T, _ := strconv.Atoi(r.FormValue("T"))
-		Wvt, _ := strconv.ParseFloat(r.FormValue("Wvt"), 64)
+		form := loc.Form(r)
+		in := losses.Input{
+			Pwt: form.Float("pwt"),
+			Kp:  form.Float("kp"),
+			T:   form.Int("t"),
+			Wvt: form.Float("wvt"),
+		}
 
 		// Обчислення
-		data.Pwt, data.Kp, data.T, data.Wvt = Pwt, Kp, T, Wvt
-		data.Mav, data.MWvt, data.Mtotal = calculateLosses(Pwt, Kp, T, Wvt)
-		data.Calculated = true
+		data.Form = form
+		if form.Valid() {
+			if res, err := losses.Calculate(in); err != nil {
+				form.Fail(err)
+			} else {
+				data.Result = &res
+			}
+		}
 	}
 
 	// Відображення сторінки
@@ -86,9 +110,3 @@ func handler(w http.ResponseWriter, r *http.Request) {
 	}
 	t.Execute(w, data)
 }
-
-func main() {
-	http.HandleFunc("/", handler)
-	log.Println("Сервер запущено на http://localhost:8080")
-	log.Fatal(http.ListenAndServe(":8080", nil))
-}
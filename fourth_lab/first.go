@@ -1,60 +1,54 @@
-package main
+package fourthlab
 
 import (
-	"fmt"
-	"math"
+	"html/template"
 	"net/http"
-	"strconv"
-	"text/template"
-)
-
-var CosPhi float64
 
-func init() {
-	CosPhi = math.Sqrt(3)
-}
+	"Go_tutor/cable"
+	"Go_tutor/i18n"
+	"Go_tutor/number"
+	"Go_tutor/validate"
+)
 
-type Results struct {
-	Izm    float64
-	IzmMax float64
-	Sek    float64
-	Smin   float64
-	Valid  bool
+var cableSpecs = number.Specs{
+	"izm":     {Precision: 2, Unit: "unit.a"},
+	"izm_max": {Precision: 2, Unit: "unit.a"},
+	"sek":     {Precision: 2, Unit: "unit.mm2"},
+	"smin":    {Precision: 2, Unit: "unit.mm2"},
 }
 
-func calculate(Sm, Unom, Kz, Ft, Jek, Ct float64) Results {
-	Izm := Sm / (CosPhi * Unom) * 1000.0 // Convert to Amps
-	IzmMax := 2 * Izm
-	Sek := Izm / Jek
-	Smin := (Kz * 1000.0 * math.Sqrt(Ft)) / Ct
-	return Results{
-		Izm:    Izm,
-		IzmMax: IzmMax,
-		Sek:    Sek,
-		Smin:   Smin,
-		Valid:  Sek >= Smin,
-	}
+// Дані сторінки з формою
+type formPage struct {
+	i18n.Localizer
+	Form *validate.Form
 }
 
-func handler(w http.ResponseWriter, r *http.Request) {
+func CableHandler(w http.ResponseWriter, r *http.Request) {
+	loc := i18n.FromRequest(w, r)
+	var form *validate.Form
 	if r.Method == http.MethodPost {
-		r.ParseForm()
-		Sm, _ := strconv.ParseFloat(r.FormValue("Sm"), 64)
-		Unom, _ := strconv.ParseFloat(r.FormValue("Unom"), 64)
-		Kz, _ := strconv.ParseFloat(r.FormValue("Kz"), 64)
-		Ft, _ := strconv.ParseFloat(r.FormValue("Ft"), 64)
-		Jek, _ := strconv.ParseFloat(r.FormValue("Jek"), 64)
-		Ct, _ := strconv.ParseFloat(r.FormValue("Ct"), 64)
-
-		results := calculate(Sm, Unom, Kz, Ft, Jek, Ct)
+		form = loc.Form(r)
+		in := cable.Input{
+			Sm:   form.Float("sm"),
+			Unom: form.Float("unom"),
+			Kz:   form.Float("kz"),
+			Ft:   form.Float("ft"),
+			Jek:  form.Float("jek"),
+			Ct:   form.Float("ct"),
+		}
 
-		tmpl := template.Must(template.New("result").Parse(`
+		if form.Valid() {
+			results, err := cable.Calculate(in)
+			if err != nil {
+				form.Fail(err)
+			} else {
+				tmpl := template.Must(template.New("result").Parse(`
 		<!DOCTYPE html>
-		<html lang="en">
+		<html lang="{{.Lang}}">
 		<head>
 			<meta charset="UTF-8">
 			<meta name="viewport" content="width=device-width, initial-scale=1.0">
-			<title>Power Calculation</title>
+			<title>{{.T "cable.title"}}</title>
 			<style>
 				body { font-family: Arial, sans-serif; margin: 20px; text-align: center; }
 				.container { max-width: 500px; margin: auto; padding: 20px; border: 1px solid #ccc; border-radius: 10px; box-shadow: 2px 2px 10px rgba(0,0,0,0.1); }
@@ -66,56 +60,59 @@ func handler(w http.ResponseWriter, r *http.Request) {
 		</head>
 		<body>
 			<div class="container">
-				<h2>Power Calculation Results</h2>
-				<p class="result">Operational Current: <b>{{printf "%.2f" .Izm}} A</b></p>
-				<p class="result">Max Starting Current: <b>{{printf "%.2f" .IzmMax}} A</b></p>
-				<p class="result">Economic Cable Section: <b>{{printf "%.2f" .Sek}} mm²</b></p>
-				<p class="result">Min Cable Section (Thermal Stability): <b>{{printf "%.2f" .Smin}} mm²</b></p>
+				<h2>{{.T "cable.results"}}</h2>
+				<p class="result">{{.T "cable.izm"}}: <b>{{.Num.Format "izm" .Izm}}</b></p>
+				<p class="result">{{.T "cable.izm_max"}}: <b>{{.Num.Format "izm_max" .IzmMax}}</b></p>
+				<p class="result">{{.T "cable.sek"}}: <b>{{.Num.Format "sek" .Sek}}</b></p>
+				<p class="result">{{.T "cable.smin"}}: <b>{{.Num.Format "smin" .Smin}}</b></p>
 				<p class="result {{if .Valid}}valid{{else}}invalid{{end}}">
-					{{if .Valid}}Selected cable section meets requirements.{{else}}Cable section needs to be increased!{{end}}
+					{{if .Valid}}{{.T "cable.valid"}}{{else}}{{.T "cable.invalid"}}{{end}}
 				</p>
-				<a href="/">Back</a>
+				<a href="./">{{.T "common.back"}}</a>
 			</div>
 		</body>
 		</html>
 		`))
-		tmpl.Execute(w, results)
-		return
+				tmpl.Execute(w, struct {
+					i18n.Localizer
+					cable.Result
+					Num number.Formatter
+				}{loc, results, loc.Formatter(r, cableSpecs)})
+				return
+			}
+		}
 	}
 
 	tmpl := template.Must(template.New("form").Parse(`
 	<!DOCTYPE html>
-	<html lang="en">
+	<html lang="{{.Lang}}">
 	<head>
 		<meta charset="UTF-8">
 		<meta name="viewport" content="width=device-width, initial-scale=1.0">
-		<title>Power Calculation</title>
+		<title>{{.T "cable.title"}}</title>
 		<style>
 			body { font-family: Arial, sans-serif; margin: 20px; text-align: center; }
 			.container { max-width: 500px; margin: auto; padding: 20px; border: 1px solid #ccc; border-radius: 10px; box-shadow: 2px 2px 10px rgba(0,0,0,0.1); }
+			.error { color: red; }
 		</style>
 	</head>
 	<body>
+		{{.Switcher}}
 		<div class="container">
-			<h2>Enter Data for Calculation</h2>
+			<h2>{{.T "common.enter_data"}}</h2>
 			<form method="post">
-				<label>Sm (kVA): <input type="text" name="Sm" required></label><br>
-				<label>Unom (kV): <input type="text" name="Unom" required></label><br>
-				<label>Kz (kA): <input type="text" name="Kz" required></label><br>
-				<label>Ft (sec): <input type="text" name="Ft" required></label><br>
-				<label>Jek (A/mm²): <input type="text" name="Jek" required></label><br>
-				<label>Ct (A*sqrt(sec)/mm²): <input type="text" name="Ct" required></label><br>
-				<button type="submit">Calculate</button>
+				<label>{{.T "cable.sm"}}: <input type="text" name="sm" value="{{.Form.Value "sm"}}" required></label>{{with .Form.Error "sm"}}<span class="error">{{.}}</span>{{end}}<br>
+				<label>{{.T "cable.unom"}}: <input type="text" name="unom" value="{{.Form.Value "unom"}}" required></label>{{with .Form.Error "unom"}}<span class="error">{{.}}</span>{{end}}<br>
+				<label>{{.T "cable.kz"}}: <input type="text" name="kz" value="{{.Form.Value "kz"}}" required></label>{{with .Form.Error "kz"}}<span class="error">{{.}}</span>{{end}}<br>
+				<label>{{.T "cable.ft"}}: <input type="text" name="ft" value="{{.Form.Value "ft"}}" required></label>{{with .Form.Error "ft"}}<span class="error">{{.}}</span>{{end}}<br>
+				<label>{{.T "cable.jek"}}: <input type="text" name="jek" value="{{.Form.Value "jek"}}" required></label>{{with .Form.Error "jek"}}<span class="error">{{.}}</span>{{end}}<br>
+				<label>{{.T "cable.ct"}}: <input type="text" name="ct" value="{{.Form.Value "ct"}}" required></label>{{with .Form.Error "ct"}}<span class="error">{{.}}</span>{{end}}<br>
+				{{with .Form.Error ""}}<p class="error">{{.}}</p>{{end}}
+				<button type="submit">{{.T "common.calculate"}}</button>
 			</form>
 		</div>
 	</body>
 	</html>
 	`))
-	tmpl.Execute(w, nil)
-}
-
-func main() {
-	http.HandleFunc("/", handler)
-	fmt.Println("Server is running on http://localhost:8080")
-	http.ListenAndServe(":8080", nil)
+	tmpl.Execute(w, formPage{loc, form})
 }
@@ -1,58 +1,49 @@
-package main
+package fourthlab
 
 import (
-	"fmt"
-	"math"
+	"html/template"
 	"net/http"
-	"strconv"
-	"text/template"
-)
 
-type Results struct {
-	XSum   float64
-	Ik0    float64
-	XcPU   float64
-	XtPU   float64
-	XSumPU float64
-	Ik0PU  float64
-}
+	"Go_tutor/i18n"
+	"Go_tutor/number"
+	"Go_tutor/shortcircuit"
+	"Go_tutor/validate"
+)
 
-func calculate(Unom, Sk, Xc, Xt, Sb float64) Results {
-	XSum := Xc + Xt
-	Ik0 := (Unom * 1000) / (math.Sqrt(3) * XSum)
-	XcPU := Xc * (Sb / Sk)
-	XtPU := Xt * (Sb / Sk)
-	XSumPU := XcPU + XtPU
-	Ib := Sb / (math.Sqrt(3) * Unom)
-	Ik0PU := Ik0 / Ib
-	return Results{
-		XSum:   XSum,
-		Ik0:    Ik0,
-		XcPU:   XcPU,
-		XtPU:   XtPU,
-		XSumPU: XSumPU,
-		Ik0PU:  Ik0PU,
-	}
+var shortCircuitSpecs = number.Specs{
+	"x_sum":    {Precision: 2, Unit: "unit.ohm"},
+	"ik0":      {Precision: 2, Unit: "unit.ka"},
+	"xc_pu":    {Precision: 3},
+	"xt_pu":    {Precision: 3},
+	"x_sum_pu": {Precision: 3},
+	"ik0_pu":   {Precision: 3},
 }
 
-func handler(w http.ResponseWriter, r *http.Request) {
+func ShortCircuitHandler(w http.ResponseWriter, r *http.Request) {
+	loc := i18n.FromRequest(w, r)
+	var form *validate.Form
 	if r.Method == http.MethodPost {
-		r.ParseForm()
-		Unom, _ := strconv.ParseFloat(r.FormValue("Unom"), 64)
-		Sk, _ := strconv.ParseFloat(r.FormValue("Sk"), 64)
-		Xc, _ := strconv.ParseFloat(r.FormValue("Xc"), 64)
-		Xt, _ := strconv.ParseFloat(r.FormValue("Xt"), 64)
-		Sb, _ := strconv.ParseFloat(r.FormValue("Sb"), 64)
+		form = loc.Form(r)
+		in := shortcircuit.Input{
+			Unom: form.Float("unom"),
+			Sk:   form.Float("sk"),
+			Xc:   form.Float("xc"),
+			Xt:   form.Float("xt"),
+			Sb:   form.Float("sb"),
+		}
 
-		results := calculate(Unom, Sk, Xc, Xt, Sb)
-
-		tmpl := template.Must(template.New("result").Parse(`
+		if form.Valid() {
+			results, err := shortcircuit.Calculate(in)
+			if err != nil {
+				form.Fail(err)
+			} else {
+				tmpl := template.Must(template.New("result").Parse(`
 		<!DOCTYPE html>
-		<html lang="en">
+		<html lang="{{.Lang}}">
 		<head>
 			<meta charset="UTF-8">
 			<meta name="viewport" content="width=device-width, initial-scale=1.0">
-			<title>Short Circuit Calculation</title>
+			<title>{{.T "sc.title"}}</title>
 			<style>
 				body { font-family: Arial, sans-serif; margin: 20px; text-align: center; }
 				.container { max-width: 500px; margin: auto; padding: 20px; border: 1px solid #ccc; border-radius: 10px; box-shadow: 2px 2px 10px rgba(0,0,0,0.1); }
@@ -60,55 +51,57 @@ func handler(w http.ResponseWriter, r *http.Request) {
 		</head>
 		<body>
 			<div class="container">
-				<h2>Short Circuit Calculation Results</h2>
-				<p>Σ Impedance at K1: <b>{{printf "%.2f" .XSum}} Ω</b></p>
-				<p>Initial Short-Circuit Current: <b>{{printf "%.2f" .Ik0}} kA</b></p>
-				<p>Xc in PU: <b>{{printf "%.2f" .XcPU}}</b></p>
-				<p>Xt in PU: <b>{{printf "%.2f" .XtPU}}</b></p>
-				<p>Σ Impedance in PU: <b>{{printf "%.2f" .XSumPU}}</b></p>
-				<p>Initial Short-Circuit Current in PU: <b>{{printf "%.2f" .Ik0PU}}</b></p>
-				<a href="/">Back</a>
+				<h2>{{.T "sc.results"}}</h2>
+				<p>{{.T "sc.x_sum"}}: <b>{{.Num.Format "x_sum" .XSum}}</b></p>
+				<p>{{.T "sc.ik0"}}: <b>{{.Num.Format "ik0" .Ik0}}</b></p>
+				<p>{{.T "sc.xc_pu"}}: <b>{{.Num.Format "xc_pu" .XcPU}}</b></p>
+				<p>{{.T "sc.xt_pu"}}: <b>{{.Num.Format "xt_pu" .XtPU}}</b></p>
+				<p>{{.T "sc.x_sum_pu"}}: <b>{{.Num.Format "x_sum_pu" .XSumPU}}</b></p>
+				<p>{{.T "sc.ik0_pu"}}: <b>{{.Num.Format "ik0_pu" .Ik0PU}}</b></p>
+				<a href="./">{{.T "common.back"}}</a>
 			</div>
 		</body>
 		</html>
 		`))
-		tmpl.Execute(w, results)
-		return
+				tmpl.Execute(w, struct {
+					i18n.Localizer
+					shortcircuit.Result
+					Num number.Formatter
+				}{loc, results, loc.Formatter(r, shortCircuitSpecs)})
+				return
+			}
+		}
 	}
 
 	tmpl := template.Must(template.New("form").Parse(`
 	<!DOCTYPE html>
-	<html lang="en">
+	<html lang="{{.Lang}}">
 	<head>
 		<meta charset="UTF-8">
 		<meta name="viewport" content="width=device-width, initial-scale=1.0">
-		<title>Short Circuit Calculation</title>
+		<title>{{.T "sc.title"}}</title>
 		<style>
 			body { font-family: Arial, sans-serif; margin: 20px; text-align: center; }
 			.container { max-width: 500px; margin: auto; padding: 20px; border: 1px solid #ccc; border-radius: 10px; box-shadow: 2px 2px 10px rgba(0,0,0,0.1); }
+			.error { color: red; }
 		</style>
 	</head>
 	<body>
+		{{.Switcher}}
 		<div class="container">
-			<h2>Enter Data for Calculation</h2>
+			<h2>{{.T "common.enter_data"}}</h2>
 			<form method="post">
-				<label>Unom (kV): <input type="text" name="Unom" required></label><br>
-				<label>Sk (MVA): <input type="text" name="Sk" required></label><br>
-				<label>Xc (Ohm): <input type="text" name="Xc" required></label><br>
-				<label>Xt (Ohm): <input type="text" name="Xt" required></label><br>
-				<label>Sb (MVA): <input type="text" name="Sb" required></label><br>
-				<button type="submit">Calculate</button>
+				<label>{{.T "sc.unom"}}: <input type="text" name="unom" value="{{.Form.Value "unom"}}" required></label>{{with .Form.Error "unom"}}<span class="error">{{.}}</span>{{end}}<br>
+				<label>{{.T "sc.sk"}}: <input type="text" name="sk" value="{{.Form.Value "sk"}}" required></label>{{with .Form.Error "sk"}}<span class="error">{{.}}</span>{{end}}<br>
+				<label>{{.T "sc.xc"}}: <input type="text" name="xc" value="{{.Form.Value "xc"}}" required></label>{{with .Form.Error "xc"}}<span class="error">{{.}}</span>{{end}}<br>
+				<label>{{.T "sc.xt"}}: <input type="text" name="xt" value="{{.Form.Value "xt"}}" required></label>{{with .Form.Error "xt"}}<span class="error">{{.}}</span>{{end}}<br>
+				<label>{{.T "sc.sb"}}: <input type="text" name="sb" value="{{.Form.Value "sb"}}" required></label>{{with .Form.Error "sb"}}<span class="error">{{.}}</span>{{end}}<br>
+				{{with .Form.Error ""}}<p class="error">{{.}}</p>{{end}}
+				<button type="submit">{{.T "common.calculate"}}</button>
 			</form>
 		</div>
 	</body>
 	</html>
 	`))
-	tmpl.Execute(w, nil)
-}
-
-func main() {
-	http.HandleFunc("/", handler)
-	fmt.Println("Server is running on http://localhost:8080")
-	http.ListenAndServe(":8080", nil)
+	tmpl.Execute(w, formPage{loc, form})
 }
-    
\ No newline at end of file
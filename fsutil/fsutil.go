@@ -0,0 +1,29 @@
+// Пакет fsutil містить спільні операції з файлами довідників і журналів.
+package fsutil
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// WriteFileAtomic записує файл path через тимчасовий файл у тому самому
+// каталозі, який після успішного запису перейменовується на path. Якщо
+// write чи закриття файлу завершилися помилкою, попередній вміст path
+// лишається без змін.
+func WriteFileAtomic(path string, write func(io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	err = write(tmp)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,194 @@
+package fuel
+
+import "Go_tutor/validate"
+
+// Маси палива
+const (
+	BasisWorking = "working" // Робоча маса
+	BasisDry     = "dry"     // Суха маса
+	BasisDAF     = "daf"     // Суха беззольна (горюча) маса
+	BasisOrganic = "organic" // Органічна маса: горюча без колчеданної сірки
+)
+
+// Bases повертає маси в порядку від робочої до органічної.
+func Bases() []string {
+	return []string{BasisWorking, BasisDry, BasisDAF, BasisOrganic}
+}
+
+// BasisInput — елементний склад палива на масу Basis (%) разом із
+// вологістю робочої маси, зольністю та вмістом колчеданної сірки на суху
+// масу, які потрібні для переходу між масами. Для органічної маси S —
+// органічна сірка.
+type BasisInput struct {
+	Basis string  `json:"basis"`
+	H     float64 `json:"h"`
+	C     float64 `json:"c"`
+	S     float64 `json:"s"`
+	N     float64 `json:"n"`
+	O     float64 `json:"o"`
+	W     float64 `json:"w"`            // Вологість робочої маси W^r
+	A     float64 `json:"a"`            // Зольність сухої маси A^d
+	SK    float64 `json:"sk,omitempty"` // Колчеданна сірка на суху масу S_k^d
+}
+
+// Composition — склад палива на одну масу, % та теплота згоряння за
+// формулою Менделєєва, МДж/кг.
+type Composition struct {
+	H   float64 `json:"h"`
+	C   float64 `json:"c"`
+	S   float64 `json:"s"`
+	N   float64 `json:"n"`
+	O   float64 `json:"o"`
+	A   float64 `json:"a"`   // Зола (лише робоча та суха маси)
+	W   float64 `json:"w"`   // Волога (лише робоча маса)
+	HHV float64 `json:"hhv"` // Вища теплота згоряння
+	LHV float64 `json:"lhv"` // Нижча теплота згоряння
+}
+
+// BasisResult — склад на кожну масу та матриця коефіцієнтів перерахунку:
+// Coefficients[from][to] множить вміст компонента на масі from, щоб
+// отримати його вміст на масі to.
+type BasisResult struct {
+	Compositions map[string]Composition        `json:"compositions"`
+	Coefficients map[string]map[string]float64 `json:"coefficients"`
+}
+
+// Validate перевіряє склад і параметри переходу між масами.
+func (in BasisInput) Validate() error {
+	errs := validate.Errors{}
+	switch in.Basis {
+	case BasisWorking, BasisDry, BasisDAF, BasisOrganic:
+	default:
+		errs.Add("basis", "must be working, dry, daf or organic")
+	}
+	errs.Percent("h", in.H)
+	errs.Percent("c", in.C)
+	errs.Percent("s", in.S)
+	errs.Percent("n", in.N)
+	errs.Percent("o", in.O)
+	errs.Range("w", in.W, 0, 99)
+	errs.Range("a", in.A, 0, 99)
+	errs.Percent("sk", in.SK)
+	if in.A+in.SK >= 100 {
+		errs.Add("sk", "ash and pyritic sulfur together must be less than 100%%")
+	}
+	if len(errs) == 0 {
+		// Зола й волога входять до складу лише робочої та сухої мас
+		m := in.shares()
+		sum := in.H + in.C + in.S + in.N + in.O
+		switch in.Basis {
+		case BasisWorking:
+			sum += in.A*m[BasisDry] + in.W
+		case BasisDry:
+			sum += in.A
+		}
+		checkSum(errs, sum)
+		if in.Basis != BasisOrganic && in.S*m[in.Basis]/m[BasisDry] < in.SK {
+			errs.Add("sk", "pyritic sulfur must not exceed total sulfur")
+		}
+	}
+	return errs.Err()
+}
+
+// shares повертає частку кожної маси в робочій масі палива.
+func (in BasisInput) shares() map[string]float64 {
+	dry := (100 - in.W) / 100
+	return map[string]float64{
+		BasisWorking: 1,
+		BasisDry:     dry,
+		BasisDAF:     dry * (100 - in.A) / 100,
+		BasisOrganic: dry * (100 - in.A - in.SK) / 100,
+	}
+}
+
+// CalculateBases перераховує склад палива з маси in.Basis на всі інші
+// маси та визначає теплоту згоряння на кожній з них.
+func CalculateBases(in BasisInput) (BasisResult, error) {
+	if err := in.Validate(); err != nil {
+		return BasisResult{}, err
+	}
+
+	m := in.shares()
+	res := BasisResult{
+		Compositions: make(map[string]Composition, len(m)),
+		Coefficients: make(map[string]map[string]float64, len(m)),
+	}
+	for _, from := range Bases() {
+		res.Coefficients[from] = make(map[string]float64, len(m))
+		for _, to := range Bases() {
+			res.Coefficients[from][to] = m[from] / m[to]
+		}
+	}
+
+	// Перераховуємо через суху масу; колчеданна сірка не входить до
+	// органічної маси
+	k := res.Coefficients[in.Basis][BasisDry]
+	sd := in.S * k
+	if in.Basis == BasisOrganic {
+		sd += in.SK
+	}
+	dry := Composition{H: in.H * k, C: in.C * k, S: sd, N: in.N * k, O: in.O * k, A: in.A}
+
+	for _, basis := range Bases() {
+		k := res.Coefficients[BasisDry][basis]
+		c := Composition{H: dry.H * k, C: dry.C * k, S: dry.S * k, N: dry.N * k, O: dry.O * k}
+		switch basis {
+		case BasisWorking:
+			c.A, c.W = dry.A*k, in.W
+		case BasisDry:
+			c.A = dry.A
+		case BasisOrganic:
+			c.S = (dry.S - in.SK) * k
+		}
+		c.HHV, c.LHV = mendeleev(c)
+		res.Compositions[basis] = c
+	}
+	return res, nil
+}
+
+// mendeleev повертає вищу та нижчу теплоту згоряння (МДж/кг) за формулою
+// Менделєєва.
+func mendeleev(c Composition) (hhv, lhv float64) {
+	for _, t := range mendeleevTerms(c) {
+		lhv += t.Contribution
+	}
+	// Вища теплота включає теплоту конденсації водяної пари
+	hhv = lhv + 0.025*(9*c.H+c.W)
+	return hhv, lhv
+}
+
+// mendeleevTerms розкладає нижчу теплоту згоряння
+// Q = 339C + 1030H − 108,8(O − S) − 25W (кДж/кг) на доданки.
+func mendeleevTerms(c Composition) []Term {
+	terms := []Term{
+		{Symbol: "C", Coefficient: 339, Value: c.C},
+		{Symbol: "H", Coefficient: 1030, Value: c.H},
+		{Symbol: "O − S", Coefficient: -108.8, Value: c.O - c.S},
+		{Symbol: "W", Coefficient: -25, Value: c.W},
+	}
+	for i := range terms {
+		terms[i].Contribution = terms[i].Coefficient * terms[i].Value / 1000
+	}
+	return terms
+}
+
+// Get повертає вміст компонента за його позначенням: h, c, s, n, o, a або w.
+func (c Composition) Get(component string) float64 {
+	switch component {
+	case "h":
+		return c.H
+	case "c":
+		return c.C
+	case "s":
+		return c.S
+	case "n":
+		return c.N
+	case "o":
+		return c.O
+	case "a":
+		return c.A
+	case "w":
+		return c.W
+	}
+	return 0
+}
@@ -0,0 +1,39 @@
+package fuel
+
+// Коефіцієнт надлишку повітря за замовчуванням
+const DefaultExcessAir = 1.2
+
+// Combustion — об'єми повітря та продуктів згоряння 1 кг палива за
+// нормальних умов, м³/кг.
+type Combustion struct {
+	Alpha float64 `json:"alpha"` // Коефіцієнт надлишку повітря
+	V0    float64 `json:"v0"`    // Теоретично необхідне повітря
+	Air   float64 `json:"air"`   // Дійсна кількість повітря
+	CO2   float64 `json:"co2"`   // Вуглекислий газ
+	SO2   float64 `json:"so2"`   // Сірчистий ангідрид
+	N2    float64 `json:"n2"`    // Азот
+	H2O   float64 `json:"h2o"`   // Водяна пара
+	O2    float64 `json:"o2"`    // Надлишковий кисень
+	Total float64 `json:"total"` // Усі димові гази
+	Dry   float64 `json:"dry"`   // Сухі димові гази
+}
+
+// combustion розраховує горіння палива складу c (робоча маса, %) з
+// коефіцієнтом надлишку повітря alpha.
+func combustion(c Composition, alpha float64) Combustion {
+	v0 := 0.0889*(c.C+0.375*c.S) + 0.265*c.H - 0.0333*c.O
+	r := Combustion{
+		Alpha: alpha,
+		V0:    v0,
+		Air:   alpha * v0,
+		CO2:   0.01866 * c.C,
+		SO2:   0.007 * c.S,
+		N2:    0.79*alpha*v0 + 0.008*c.N,
+		// Волога палива, водень та волога повітря
+		H2O: 0.111*c.H + 0.0124*c.W + 0.0161*alpha*v0,
+		O2:  0.21 * (alpha - 1) * v0,
+	}
+	r.Dry = r.CO2 + r.SO2 + r.N2 + r.O2
+	r.Total = r.Dry + r.H2O
+	return r
+}
@@ -0,0 +1,128 @@
+// Пакет fuel перераховує склад твердого палива та мазуту між масами
+// і визначає нижчу теплоту згоряння.
+package fuel
+
+import (
+	"math"
+
+	"Go_tutor/validate"
+)
+
+// Допустиме відхилення суми компонентів складу від 100 %
+const SumTolerance = 0.5
+
+// Склад палива на робочу масу, %
+type Input struct {
+	HP float64 `json:"hp"`
+	CP float64 `json:"cp"`
+	SP float64 `json:"sp"`
+	NP float64 `json:"np"`
+	OP float64 `json:"op"`
+	WP float64 `json:"wp"`
+	AP float64 `json:"ap"`
+	// Коефіцієнт надлишку повітря; 0 — DefaultExcessAir
+	Alpha float64 `json:"alpha,omitempty"`
+}
+
+// Результати перерахунку на суху та горючу масу
+type Result struct {
+	// Коефіцієнти переходу до сухої та горючої маси
+	KRS float64 `json:"krs"`
+	KRG float64 `json:"krg"`
+	// Склад сухої маси, %
+	HC float64 `json:"hc"`
+	CC float64 `json:"cc"`
+	SC float64 `json:"sc"`
+	NC float64 `json:"nc"`
+	OC float64 `json:"oc"`
+	AC float64 `json:"ac"`
+	// Склад горючої маси, %
+	HG float64 `json:"hg"`
+	CG float64 `json:"cg"`
+	SG float64 `json:"sg"`
+	NG float64 `json:"ng"`
+	OG float64 `json:"og"`
+	// Нижча теплота згоряння робочої, сухої та горючої маси, МДж/кг
+	QrH   float64 `json:"qrh"`
+	QdH   float64 `json:"qdh"`
+	QdafH float64 `json:"qdafh"`
+	// Вища теплота згоряння робочої маси, МДж/кг
+	QrV float64 `json:"qrv"`
+	// Доданки формули Менделєєва для QrH
+	Terms []Term `json:"terms"`
+	// Повітря та димові гази
+	Combustion Combustion `json:"combustion"`
+}
+
+// Term — доданок формули Менделєєва: коефіцієнт (кДж/кг на 1 %),
+// вміст компонента (%) та внесок у теплоту згоряння (МДж/кг).
+type Term struct {
+	Symbol       string  `json:"symbol"`
+	Coefficient  float64 `json:"coefficient"`
+	Value        float64 `json:"value"`
+	Contribution float64 `json:"contribution"`
+}
+
+// Validate перевіряє, що компоненти задані у відсотках і разом дають 100 %.
+func (in Input) Validate() error {
+	errs := validate.Errors{}
+	errs.Percent("hp", in.HP)
+	errs.Percent("cp", in.CP)
+	errs.Percent("sp", in.SP)
+	errs.Percent("np", in.NP)
+	errs.Percent("op", in.OP)
+	errs.Percent("wp", in.WP)
+	errs.Percent("ap", in.AP)
+	if in.WP+in.AP >= 100 {
+		errs.Add("wp", "moisture and ash together must be less than 100%%")
+	}
+	if in.Alpha != 0 {
+		errs.Range("alpha", in.Alpha, 1, 3)
+	}
+	checkSum(errs, in.HP+in.CP+in.SP+in.NP+in.OP+in.WP+in.AP)
+	return errs.Err()
+}
+
+// Calculate перераховує склад робочої маси на суху та горючу.
+func Calculate(in Input) (Result, error) {
+	if err := in.Validate(); err != nil {
+		return Result{}, err
+	}
+
+	var r Result
+	r.KRS = 100 / (100 - in.WP)
+	r.KRG = 100 / (100 - in.WP - in.AP)
+
+	r.HC = in.HP * r.KRS
+	r.CC = in.CP * r.KRS
+	r.SC = in.SP * r.KRS
+	r.NC = in.NP * r.KRS
+	r.OC = in.OP * r.KRS
+	r.AC = in.AP * r.KRS
+
+	r.HG = in.HP * r.KRG
+	r.CG = in.CP * r.KRG
+	r.SG = in.SP * r.KRG
+	r.NG = in.NP * r.KRG
+	r.OG = in.OP * r.KRG
+
+	c := Composition{H: in.HP, C: in.CP, S: in.SP, N: in.NP, O: in.OP, W: in.WP}
+	r.Terms = mendeleevTerms(c)
+	r.QrV, r.QrH = mendeleev(c)
+	// Теплота випаровування вологи не віднімається для сухої та горючої мас
+	r.QdH = (r.QrH + 0.025*in.WP) * r.KRS
+	r.QdafH = (r.QrH + 0.025*in.WP) * r.KRG
+
+	alpha := in.Alpha
+	if alpha == 0 {
+		alpha = DefaultExcessAir
+	}
+	r.Combustion = combustion(c, alpha)
+	return r, nil
+}
+
+func checkSum(errs validate.Errors, sum float64) {
+	if math.Abs(sum-100) > SumTolerance {
+		errs.Add("composition", "components must sum to 100%%, got %.2f%%", sum)
+	}
+}
@@ -0,0 +1,91 @@
+package fuel
+
+import (
+	"math"
+
+	"Go_tutor/validate"
+)
+
+// Склад горючої маси мазуту, %, теплота згоряння горючої маси, вологість
+// робочої маси, зольність сухої маси та вміст ванадію в сухій масі (мг/кг)
+type MazutInput struct {
+	H float64 `json:"h"`
+	C float64 `json:"c"`
+	S float64 `json:"s"`
+	N float64 `json:"n"`
+	Q float64 `json:"q"`
+	O float64 `json:"o"`
+	W float64 `json:"w"`
+	A float64 `json:"a"`
+	V float64 `json:"v"`
+}
+
+// Склад робочої маси мазуту, % та вміст ванадію в робочій масі, мг/кг
+type MazutResult struct {
+	C float64 `json:"c"`
+	H float64 `json:"h"`
+	O float64 `json:"o"`
+	S float64 `json:"s"`
+	N float64 `json:"n"`
+	A float64 `json:"a"`
+	Q float64 `json:"q"`
+	V float64 `json:"v"`
+	// Відхилення суми складу горючої маси від 100 %
+	Residual float64 `json:"residual"`
+}
+
+// Validate перевіряє склад мазуту. Склад горючої маси має в межах
+// SumTolerance давати 100 %.
+func (in MazutInput) Validate() error {
+	errs := validate.Errors{}
+	errs.Percent("h", in.H)
+	errs.Percent("c", in.C)
+	errs.Percent("s", in.S)
+	errs.Percent("n", in.N)
+	errs.Percent("o", in.O)
+	errs.Percent("w", in.W)
+	errs.Percent("a", in.A)
+	errs.Positive("q", in.Q)
+	errs.NonNegative("v", in.V)
+	if in.W+in.workingAsh() >= 100 {
+		errs.Add("w", "moisture and ash together must be less than 100%%")
+	}
+	if r := in.residual(); math.Abs(r) > SumTolerance {
+		errs.Add("composition", "combustible composition must sum to 100%%, residual %.2f%%", r)
+	}
+	return errs.Err()
+}
+
+// workingAsh перераховує зольність сухої маси на робочу: A_r = A·(100 − W)/100.
+func (in MazutInput) workingAsh() float64 {
+	return in.A * (100 - in.W) / 100
+}
+
+// residual повертає різницю між 100 % і сумою складу горючої маси.
+func (in MazutInput) residual() float64 {
+	return 100 - (in.H + in.C + in.S + in.N + in.O)
+}
+
+// CalculateMazut перераховує склад горючої маси мазуту на робочу.
+func CalculateMazut(in MazutInput) (MazutResult, error) {
+	if err := in.Validate(); err != nil {
+		return MazutResult{}, err
+	}
+
+	// Зола та ванадій задані на суху масу, тому спершу перераховуються на
+	// робочу, а коефіцієнт для горючої маси враховує вже робочу зольність
+	ash := in.workingAsh()
+	k := (100 - in.W - ash) / 100
+	dry := (100 - in.W) / 100
+	return MazutResult{
+		Q:        in.Q * k,
+		H:        in.H * k,
+		C:        in.C * k,
+		S:        in.S * k,
+		N:        in.N * k,
+		O:        in.O * k,
+		A:        ash,
+		V:        in.V * dry,
+		Residual: in.residual(),
+	}, nil
+}
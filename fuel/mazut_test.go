@@ -0,0 +1,52 @@
+package fuel
+
+import (
+	"math"
+	"testing"
+
+	"Go_tutor/validate"
+)
+
+func TestCalculateMazut(t *testing.T) {
+	// Приклад з методички: зола та ванадій задані на суху масу
+	in := MazutInput{H: 11.2, C: 85.5, S: 2.5, O: 0.8, Q: 40.4, W: 2, A: 0.15, V: 333.3}
+	res, err := CalculateMazut(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A_r = 0,15·0,98 = 0,147; k = (100 − 2 − 0,147)/100 = 0,97853
+	const k = 0.97853
+	tests := []struct {
+		name      string
+		got, want float64
+	}{
+		{"a", res.A, 0.147},
+		{"v", res.V, 333.3 * 0.98},
+		{"c", res.C, 85.5 * k},
+		{"h", res.H, 11.2 * k},
+		{"s", res.S, 2.5 * k},
+		{"o", res.O, 0.8 * k},
+		{"q", res.Q, 40.4 * k},
+		{"residual", res.Residual, 0},
+	}
+	for _, tt := range tests {
+		if math.Abs(tt.got-tt.want) > 1e-9 {
+			t.Errorf("%s = %.9g, want %.9g", tt.name, tt.got, tt.want)
+		}
+	}
+	// Сума складу робочої маси з вологою та робочою золою — 100 %
+	if sum := res.C + res.H + res.S + res.N + res.O + res.A + in.W; math.Abs(sum-100) > 1e-9 {
+		t.Errorf("working mass sums to %g", sum)
+	}
+}
+
+func TestCalculateMazutComposition(t *testing.T) {
+	_, err := CalculateMazut(MazutInput{H: 11, C: 80, S: 2, Q: 40, W: 2, A: 0.1})
+	errs, ok := validate.Fields(err)
+	if !ok {
+		t.Fatalf("error = %v", err)
+	}
+	if _, ok := errs["composition"]; !ok {
+		t.Errorf("no composition error: %v", err)
+	}
+}
@@ -0,0 +1,328 @@
+package i18n
+
+// Англійський каталог. Повідомлення перевірки вже англійською, тому тут
+// лише тексти інтерфейсу та назви довідникових позицій.
+var en = map[string]string{
+	"common.calculate":  "Calculate",
+	"common.clear":      "Clear results",
+	"common.results":    "Results",
+	"common.back":       "Back",
+	"common.enter_data": "Enter input data",
+
+	"unit.percent":      "%",
+	"unit.mj_per_kg":    "MJ/kg",
+	"unit.m3_per_kg":    "m³/kg",
+	"unit.mg_per_kg":    "mg/kg",
+	"unit.t":            "t",
+	"unit.g_per_gj":     "g/GJ",
+	"unit.thousand_m3":  "thousand m³",
+	"unit.t_per_year":   "t/year",
+	"unit.uah":          "UAH",
+	"unit.uah_per_t":    "UAH/t",
+	"unit.uah_per_kwh":  "UAH/kWh",
+	"unit.gj":           "GJ",
+	"unit.mw":           "MW",
+	"unit.thousand_uah": "thousand UAH",
+	"unit.a":            "A",
+	"unit.mm2":          "mm²",
+	"unit.ohm":          "Ω",
+	"unit.ka":           "kA",
+	"unit.per_year":     "yr⁻¹",
+	"unit.h":            "h",
+	"unit.mwh":          "MWh",
+	"unit.kwh":          "kWh",
+
+	"element.h": "Hydrogen",
+	"element.c": "Carbon",
+	"element.s": "Sulfur",
+	"element.n": "Nitrogen",
+	"element.o": "Oxygen",
+	"element.w": "Moisture",
+	"element.a": "Ash",
+	"element.v": "Vanadium",
+
+	"fuel.title":        "Fuel Composition Calculator",
+	"fuel.heading":      "Fuel composition",
+	"fuel.krs":          "Dry mass coefficient",
+	"fuel.krg":          "Combustible mass coefficient",
+	"fuel.dry":          "Dry composition",
+	"fuel.combustible":  "Combustible composition",
+	"fuel.lhv":          "Lower heating value",
+	"fuel.lhv_dry":      "Lower heating value of dry mass",
+	"fuel.lhv_daf":      "Lower heating value of combustible mass",
+	"fuel.hhv":          "Higher heating value",
+	"fuel.breakdown":    "Mendeleev formula breakdown",
+	"fuel.kj_note":      "kJ/kg (as-received composition, %)",
+	"fuel.term":         "Term",
+	"fuel.coefficient":  "Coefficient, kJ/kg",
+	"fuel.content":      "Content, %",
+	"fuel.contribution": "Contribution",
+	"fuel.alpha":        "Excess air ratio",
+	"fuel.combustion":   "Air and flue gas",
+	"fuel.v0":           "Theoretical air",
+	"fuel.air":          "Actual air",
+	"fuel.flue_dry":     "Dry flue gas",
+	"fuel.flue_total":   "Total flue gas",
+
+	"mazut.title":        "Fuel Oil Composition Calculator",
+	"mazut.heading":      "Fuel oil composition conversion",
+	"mazut.vanadium":     "Vanadium content of as-received mass",
+	"mazut.vanadium_dry": "Vanadium content of dry mass",
+	"mazut.ash_dry":      "Ash content of dry mass",
+	"mazut.residual":     "Combustible composition residual",
+	"batch.heading":      "Batch calculation",
+	"batch.hint":         "A CSV or XLSX table with one sample per row. Columns: %s; other columns (sample number, date) are copied to the results unchanged.",
+	"batch.submit":       "Download results",
+
+	"bases.title":   "Fuel composition on all mass bases",
+	"bases.heading": "Fuel composition on different bases",
+	"bases.source":  "Basis of the given composition",
+	"bases.working": "As received",
+	"bases.dry":     "Dry",
+	"bases.daf":     "Dry ash-free",
+	"bases.organic": "Organic",
+	"bases.w":       "As-received moisture",
+	"bases.a":       "Ash, dry basis",
+	"bases.sk":      "Pyritic sulfur, dry basis",
+	"bases.hhv":     "Higher heating value",
+	"bases.matrix":  "Conversion coefficients",
+	"bases.from_to": "From basis \\ to basis",
+
+	"emissions.title":       "Emissions Calculator",
+	"emissions.fuel":        "Fuel type",
+	"emissions.group.coal":  "Coal",
+	"emissions.group.mazut": "Fuel oil",
+	"emissions.group.gas":   "Natural gas",
+	"emissions.quantity":    "Fuel quantity",
+	"emissions.total":       "Emissions from %s",
+	"emissions.factor":      "Emission factor",
+	"emissions.gross":       "Gross emission",
+	"emissions.pollutant":   "Pollutant",
+	"emissions.burner":      "Burners",
+
+	"pollutant.particulates": "Particulate matter",
+	"pollutant.so2":          "Sulfur oxides (SO₂)",
+	"pollutant.nox":          "Nitrogen oxides (NOx)",
+	"pollutant.co2":          "Carbon dioxide (CO₂)",
+
+	"emissions.unit":               "Unit",
+	"emissions.add_fuel":           "Add fuel",
+	"emissions.energy":             "Fuel energy",
+	"emissions.sum":                "Total",
+	"emissions.boiler":             "Boiler type",
+	"emissions.by_fuel":            "By fuel type",
+	"emissions.abatement":          "Flue gas cleaning",
+	"emissions.fly_ash":            "Fly ash fraction",
+	"emissions.combustible_in_ash": "Combustibles in fly ash, %",
+	"emissions.efficiency":         "Capture efficiency",
+	"emissions.preset":             "from preset",
+
+	"boiler.chamber_dry": "Pulverised-fuel furnace, dry bottom",
+	"boiler.chamber_wet": "Pulverised-fuel furnace, wet bottom",
+	"boiler.grate":       "Grate furnace",
+	"boiler.fluid_bed":   "Fluidised bed",
+	"boiler.oil_gas":     "Oil and gas boiler",
+
+	"abatement.esp":          "Electrostatic precipitator",
+	"abatement.bag_filter":   "Bag filter",
+	"abatement.wet_scrubber": "Wet scrubber",
+	"abatement.none":         "None",
+
+	"burner.standard": "Standard",
+	"burner.low_nox":  "Low-NOx",
+	"burner.staged":   "Low-NOx with staged combustion",
+
+	"Антрацитовий штиб АШ":        "Anthracite culm (AS)",
+	"Пісне вугілля ТР":            "Lean coal (TR)",
+	"Донецьке газове ГР":          "Donetsk gas coal (GR)",
+	"Донецьке довгополуменеве ДР": "Donetsk long-flame coal (DR)",
+	"Львівсько-волинське (ЛВ) ГР": "Lviv-Volyn gas coal (GR)",
+	"Олександрійське буре БІР":    "Oleksandriia brown coal (BIR)",
+	"Високосірчастий 40":          "High-sulfur fuel oil 40",
+	"Високосірчастий 100":         "High-sulfur fuel oil 100",
+	"Високосірчастий 200":         "High-sulfur fuel oil 200",
+	"Малосірчастий 40":            "Low-sulfur fuel oil 40",
+	"Малосірчастий 100":           "Low-sulfur fuel oil 100",
+	"Уренгой—Ужгород":             "Urengoy–Uzhhorod pipeline gas",
+	"Середня Азія—Центр":          "Central Asia–Centre pipeline gas",
+
+	"catalog.title":   "Fuel catalog",
+	"catalog.name":    "Name",
+	"catalog.type":    "Type",
+	"catalog.density": "Density (kg/m³, gas — kg/Nm³)",
+	"catalog.edit":    "Edit",
+	"catalog.delete":  "Delete",
+	"catalog.save":    "Add or update grade",
+
+	"ledger.title":    "Emissions ledger",
+	"ledger.plant":    "Plant",
+	"ledger.block":    "Unit",
+	"ledger.year":     "Year",
+	"ledger.month":    "Month",
+	"ledger.through":  "Through month",
+	"ledger.all":      "All",
+	"ledger.show":     "Show",
+	"ledger.ytd":      "Emissions for %d (year to date through month %s)",
+	"ledger.by_fuel":  "By fuel",
+	"ledger.by_month": "By month",
+	"ledger.entries":  "Entries",
+	"ledger.period":   "Period",
+	"ledger.empty":    "No entries for this year.",
+	"ledger.add":      "Add entry",
+
+	"tax.title":     "Environmental tax rates",
+	"tax.heading":   "Environmental tax for %d",
+	"tax.year":      "Tax year",
+	"tax.rate":      "Rate",
+	"tax.amount":    "Tax amount",
+	"tax.rate_year": "Rates in force since %d applied.",
+	"tax.note":      "Rates apply from the given year until the year of the next rates.",
+	"tax.save":      "Add or update rates",
+
+	"permit.title":           "Emission permits",
+	"permit.check":           "Permit check",
+	"permit.kind":            "Limit type",
+	"permit.kind.annual":     "Gross emission, t/year",
+	"permit.kind.factor":     "Emission factor, g/GJ",
+	"permit.value":           "Emission",
+	"permit.limit":           "Limit",
+	"permit.share":           "Used",
+	"permit.status":          "Status",
+	"permit.status.ok":       "Within limit",
+	"permit.status.warning":  "Approaching limit",
+	"permit.status.exceeded": "Limit exceeded",
+	"permit.warning":         "Warning threshold (share of limit)",
+	"permit.save":            "Add or update permit",
+	"permit.empty":           "No permits yet.",
+
+	"solar.title":             "Profit Calculation",
+	"solar.heading":           "Solar power plant profit calculation",
+	"solar.pc":                "Average daily power (Pc), MW",
+	"solar.delta":             "Forecast error (%)",
+	"solar.pc_result":         "Average daily power",
+	"solar.delta_result":      "Forecast error",
+	"solar.current":           "Current forecast",
+	"solar.improved":          "Improved forecast (new σ)",
+	"solar.energy_share":      "Energy share",
+	"solar.profit":            "Profit",
+	"solar.penalty":           "Penalty",
+	"solar.gain":              "You can gain %s of profit!",
+	"solar.sigma":             "Current forecast σ, MW",
+	"solar.new_sigma":         "Improved forecast σ, MW",
+	"solar.band":              "Allowed imbalance, % of Pc",
+	"solar.tariff":            "Tariff, UAH/kWh",
+	"solar.sigma_result":      "Forecast standard deviation",
+	"solar.band_result":       "Allowed imbalance",
+	"solar.tariff_result":     "Tariff",
+	"solar.distribution":      "Generation distribution",
+	"distribution.normal":     "normal",
+	"distribution.lognormal":  "lognormal",
+	"distribution.weibull":    "Weibull",
+	"distribution.beta":       "beta",
+	"distribution.empirical":  "empirical (kernel density)",
+	"fit.title":               "Forecast error from history",
+	"fit.heading":             "Forecast error distribution estimate",
+	"fit.hint":                "A CSV or XLSX table with hourly columns forecast (MW) and actual (metered, MW). Night hours without forecast or generation are skipped.",
+	"fit.hours":               "Hours used",
+	"fit.pc":                  "Mean forecast power (Pc)",
+	"fit.bias":                "Forecast bias (actual − forecast)",
+	"fit.sigma":               "Forecast error standard deviation (σ)",
+	"fit.bandwidth":           "Kernel bandwidth",
+	"fit.hours_within":        "Hours within tolerance",
+	"fit.energy_within":       "Energy of hours within tolerance",
+	"fit.normal_share":        "Energy within tolerance: normal",
+	"fit.kde_share":           "Energy within tolerance: kernel density",
+	"hourly.title":            "Hourly calculation",
+	"hourly.heading":          "Hourly solar plant profit and penalties",
+	"hourly.hint":             "Enter an hourly forecast, or only the average daily power for a typical clear-sky curve. Without hourly σ it equals the forecast error share of the hour forecast; an empty tariff means 7 UAH/kWh.",
+	"hourly.hour":             "Hour",
+	"hourly.forecast":         "Forecast, MW",
+	"hourly.day":              "Day total",
+	"hourly.flat":             "Daily average power",
+	"hourly.flat_unavailable": "unavailable: %s",
+	"monte_carlo.days":        "Monte Carlo simulation, days",
+	"monte_carlo.seed":        "Random seed",
+	"monte_carlo.heading":     "Monte Carlo simulation",
+	"monte_carlo.summary":     "Simulated days: %d, random seed: %d",
+	"monte_carlo.mean":        "Mean daily revenue",
+	"monte_carlo.loss":        "Probability of loss",
+	"monte_carlo.difference":  "Gain from improvement",
+	"monte_carlo.note":        "Daily revenue is profit minus penalty; generation for each hour is drawn from the chosen distribution. For the gain from improvement, the probability of loss is the chance that the improved forecast earns less than the current one.",
+
+	"cable.title":   "Cable Section Selection",
+	"cable.results": "Cable Selection Results",
+	"cable.sm":      "Sm (kVA)",
+	"cable.unom":    "Unom (kV)",
+	"cable.kz":      "Kz (kA)",
+	"cable.ft":      "Ft (sec)",
+	"cable.jek":     "Jek (A/mm²)",
+	"cable.ct":      "Ct (A*sqrt(sec)/mm²)",
+	"cable.izm":     "Operational current",
+	"cable.izm_max": "Max starting current",
+	"cable.sek":     "Economic cable section",
+	"cable.smin":    "Min cable section (thermal stability)",
+	"cable.valid":   "Selected cable section meets requirements.",
+	"cable.invalid": "Cable section needs to be increased!",
+
+	"sc.title":    "Short Circuit Calculation",
+	"sc.results":  "Short Circuit Calculation Results",
+	"sc.unom":     "Unom (kV)",
+	"sc.sk":       "Sk (MVA)",
+	"sc.xc":       "Xc (Ohm)",
+	"sc.xt":       "Xt (Ohm)",
+	"sc.sb":       "Sb (MVA)",
+	"sc.x_sum":    "Σ impedance at K1",
+	"sc.ik0":      "Initial short-circuit current",
+	"sc.xc_pu":    "Xc in PU",
+	"sc.xt_pu":    "Xt in PU",
+	"sc.x_sum_pu": "Σ impedance in PU",
+	"sc.ik0_pu":   "Initial short-circuit current in PU",
+
+	"rel.title":     "Reliability Calculation",
+	"rel.pv":        "Load (MW)",
+	"rel.kp":        "Utilization factor",
+	"rel.t":         "Operating time (h)",
+	"rel.equipment": "Equipment type",
+	"rel.qo":        "Failure rate",
+	"rel.tavg":      "Mean outage duration",
+	"rel.ka":        "Outage coefficient",
+	"rel.m_energy":  "Energy losses",
+
+	"ПЛ-110 кВ": "110 kV overhead line",
+	"ПЛ-35 кВ":  "35 kV overhead line",
+	"Т-110 кВ":  "110 kV transformer",
+	"Т-35 кВ":   "35 kV transformer",
+
+	"losses.title":   "Electricity Losses Calculation",
+	"losses.pwt":     "Load (MW)",
+	"losses.kp":      "Utilization factor",
+	"losses.t":       "Annual operating time (h)",
+	"losses.wvt":     "Parameter W (W)",
+	"losses.mav":     "Autotransformer energy losses",
+	"losses.m_wvt":   "Expected transmission losses",
+	"losses.m_total": "Total energy losses",
+
+	"load.title":      "Electrical Load Calculator",
+	"load.count":      "Count",
+	"load.power":      "Power (kW)",
+	"load.voltage":    "Voltage (V)",
+	"load.cos_phi":    "CosPhi",
+	"load.eta":        "Eta",
+	"load.util_coeff": "Utilization coeff.",
+	"load.tg_phi":     "TgPhi",
+	"load.kv":         "Kv",
+	"load.parameter":  "Parameter",
+	"load.value":      "Value",
+
+	"index.title":       "Power Engineering Calculators",
+	"index.fuel":        "Fuel composition (working, dry and combustible mass)",
+	"index.bases":       "Fuel composition on all mass bases",
+	"index.mazut":       "Fuel oil composition conversion",
+	"index.emissions":   "Particulate emissions",
+	"index.solar":       "Solar power plant profit",
+	"index.cable":       "Cable section selection",
+	"index.short":       "Short-circuit currents",
+	"index.reliability": "Power supply reliability",
+	"index.losses":      "Electricity losses",
+	"index.load":        "Electrical loads",
+}
@@ -0,0 +1,133 @@
+// Пакет i18n перекладає тексти інтерфейсу українською та англійською і
+// визначає мову запиту.
+package i18n
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"Go_tutor/number"
+	"Go_tutor/validate"
+)
+
+// Lang — код мови інтерфейсу.
+type Lang string
+
+const (
+	Ukrainian Lang = "uk"
+	English   Lang = "en"
+)
+
+// Default — мова за замовчуванням.
+const Default = Ukrainian
+
+// CookieName — назва cookie, у якій зберігається обрана мова.
+const CookieName = "lang"
+
+var catalogs = map[Lang]map[string]string{
+	Ukrainian: uk,
+	English:   en,
+}
+
+// Parse повертає підтримувану мову для коду на кшталт "en" чи "uk-UA".
+func Parse(code string) (Lang, bool) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	code, _, _ = strings.Cut(code, "-")
+	lang := Lang(code)
+	_, ok := catalogs[lang]
+	return lang, ok
+}
+
+// FromRequest визначає мову за параметром lang, cookie або заголовком
+// Accept-Language. Мову з параметра запам'ятовує в cookie.
+func FromRequest(w http.ResponseWriter, r *http.Request) Localizer {
+	if lang, ok := Parse(r.URL.Query().Get("lang")); ok {
+		http.SetCookie(w, &http.Cookie{Name: CookieName, Value: string(lang), Path: "/", MaxAge: 365 * 24 * 60 * 60})
+		return Localizer{lang}
+	}
+	if c, err := r.Cookie(CookieName); err == nil {
+		if lang, ok := Parse(c.Value); ok {
+			return Localizer{lang}
+		}
+	}
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		code, _, _ := strings.Cut(part, ";")
+		if lang, ok := Parse(code); ok {
+			return Localizer{lang}
+		}
+	}
+	return Localizer{Default}
+}
+
+// Localizer перекладає тексти однією мовою. Вбудовується в дані сторінок,
+// щоб шаблони могли викликати {{.T "key"}}.
+type Localizer struct {
+	Lang Lang
+}
+
+// T повертає переклад ключа. Для відсутнього ключа повертається сам ключ,
+// тож англійські повідомлення перевірки не потребують окремого перекладу.
+func (l Localizer) T(key string, args ...any) string {
+	msg := l.Format(key)
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+// Format повертає перекладений рядок формату без підстановки аргументів.
+func (l Localizer) Format(key string) string {
+	if msg, ok := catalogs[l.Lang][key]; ok {
+		return msg
+	}
+	return key
+}
+
+// Error перекладає помилку одним рядком; помилки полів об'єднуються
+// validate.Errors.Message.
+func (l Localizer) Error(err error) string {
+	if fields, ok := validate.Fields(err); ok {
+		return fields.Message(l.Format)
+	}
+	return l.T(err.Error())
+}
+
+// Number повертає правила запису чисел для мови.
+func (l Localizer) Number() number.Locale {
+	if l.Lang == English {
+		return number.English
+	}
+	return number.Ukrainian
+}
+
+// Formatter створює форматувальник результатів з перекладеними одиницями.
+func (l Localizer) Formatter(r *http.Request, specs number.Specs) number.Formatter {
+	translated := make(number.Specs, len(specs))
+	for field, spec := range specs {
+		if spec.Unit != "" {
+			spec.Unit = l.T(spec.Unit)
+		}
+		translated[field] = spec
+	}
+	return number.NewFormatter(r, l.Number(), translated)
+}
+
+// Form розбирає форму запиту з перекладом повідомлень про помилки.
+func (l Localizer) Form(r *http.Request) *validate.Form {
+	form := validate.NewForm(r)
+	form.Translate = l.Format
+	return form
+}
+
+// Switcher повертає посилання для перемикання мови.
+func (l Localizer) Switcher() template.HTML {
+	link := func(lang Lang, title string) string {
+		if lang == l.Lang {
+			return "<b>" + title + "</b>"
+		}
+		return `<a href="?lang=` + string(lang) + `">` + title + "</a>"
+	}
+	return template.HTML(`<p class="lang">` + link(Ukrainian, "Українська") + " | " + link(English, "English") + "</p>")
+}
@@ -0,0 +1,29 @@
+package i18n
+
+import (
+	"errors"
+	"testing"
+
+	"Go_tutor/validate"
+)
+
+func TestLocalizerError(t *testing.T) {
+	errs := validate.Errors{}
+	errs.Positive("q", 0)
+	errs.Range("a", 120, 0, 100)
+	loc := Localizer{Ukrainian}
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{errs, "a: має бути від 0 до 100; q: має бути більше нуля"},
+		{errors.New("permit not found"), "дозвіл не знайдено"},
+		// Повідомлення без перекладу виводиться як є
+		{errors.New("unexpected EOF"), "unexpected EOF"},
+	}
+	for _, tt := range tests {
+		if got := loc.Error(tt.err); got != tt.want {
+			t.Errorf("Error(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
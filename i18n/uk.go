@@ -0,0 +1,366 @@
+package i18n
+
+// Український каталог. Англійські повідомлення перевірки з пакетів
+// розрахунків є ключами й перекладаються тут дослівно.
+var uk = map[string]string{
+	"common.calculate":  "Розрахувати",
+	"common.clear":      "Очистити результати",
+	"common.results":    "Результати",
+	"common.back":       "Назад",
+	"common.enter_data": "Введіть початкові дані",
+
+	"unit.percent":      "%",
+	"unit.mj_per_kg":    "МДж/кг",
+	"unit.m3_per_kg":    "м³/кг",
+	"unit.mg_per_kg":    "мг/кг",
+	"unit.t":            "т",
+	"unit.g_per_gj":     "г/ГДж",
+	"unit.thousand_m3":  "тис. м³",
+	"unit.t_per_year":   "т/рік",
+	"unit.uah":          "грн",
+	"unit.uah_per_t":    "грн/т",
+	"unit.uah_per_kwh":  "грн/кВт·год",
+	"unit.gj":           "ГДж",
+	"unit.mw":           "МВт",
+	"unit.thousand_uah": "тис. грн",
+	"unit.a":            "А",
+	"unit.mm2":          "мм²",
+	"unit.ohm":          "Ом",
+	"unit.ka":           "кА",
+	"unit.per_year":     "рік⁻¹",
+	"unit.h":            "год",
+	"unit.mwh":          "МВт·год",
+	"unit.kwh":          "кВт·год",
+
+	"element.h": "Водень",
+	"element.c": "Вуглець",
+	"element.s": "Сірка",
+	"element.n": "Азот",
+	"element.o": "Кисень",
+	"element.w": "Волога",
+	"element.a": "Зола",
+	"element.v": "Ванадій",
+
+	"fuel.title":        "Калькулятор складу палива",
+	"fuel.heading":      "Склад палива",
+	"fuel.krs":          "Коефіцієнт сухої маси",
+	"fuel.krg":          "Коефіцієнт горючої маси",
+	"fuel.dry":          "Сухий склад",
+	"fuel.combustible":  "Горючий склад",
+	"fuel.lhv":          "Нижча теплота згоряння",
+	"fuel.lhv_dry":      "Нижча теплота згоряння сухої маси",
+	"fuel.lhv_daf":      "Нижча теплота згоряння горючої маси",
+	"fuel.hhv":          "Вища теплота згоряння",
+	"fuel.breakdown":    "Розрахунок за формулою Менделєєва",
+	"fuel.kj_note":      "кДж/кг (склад робочої маси, %)",
+	"fuel.term":         "Доданок",
+	"fuel.coefficient":  "Коефіцієнт, кДж/кг",
+	"fuel.content":      "Вміст, %",
+	"fuel.contribution": "Внесок",
+	"fuel.alpha":        "Коефіцієнт надлишку повітря",
+	"fuel.combustion":   "Повітря та димові гази",
+	"fuel.v0":           "Теоретично необхідне повітря",
+	"fuel.air":          "Дійсна кількість повітря",
+	"fuel.flue_dry":     "Сухі димові гази",
+	"fuel.flue_total":   "Усього димових газів",
+
+	"mazut.title":        "Калькулятор складу мазуту",
+	"mazut.heading":      "Перерахунок складу мазуту",
+	"mazut.vanadium":     "Вміст ванадію в робочій масі",
+	"mazut.vanadium_dry": "Вміст ванадію в сухій масі",
+	"mazut.ash_dry":      "Зольність сухої маси",
+	"mazut.residual":     "Нев'язка складу горючої маси",
+	"batch.heading":      "Пакетний розрахунок",
+	"batch.hint":         "Таблиця CSV або XLSX, кожен рядок — зразок. Стовпці: %s; інші стовпці (номер зразка, дата) переносяться в результат без змін.",
+	"batch.submit":       "Завантажити результати",
+
+	"bases.title":   "Перерахунок складу палива між масами",
+	"bases.heading": "Склад палива на різні маси",
+	"bases.source":  "Маса, на яку задано склад",
+	"bases.working": "Робоча",
+	"bases.dry":     "Суха",
+	"bases.daf":     "Горюча (суха беззольна)",
+	"bases.organic": "Органічна",
+	"bases.w":       "Вологість робочої маси",
+	"bases.a":       "Зольність сухої маси",
+	"bases.sk":      "Колчеданна сірка на суху масу",
+	"bases.hhv":     "Вища теплота згоряння",
+	"bases.matrix":  "Коефіцієнти перерахунку",
+	"bases.from_to": "З маси \\ на масу",
+
+	"emissions.title":       "Калькулятор викидів",
+	"emissions.fuel":        "Тип палива",
+	"emissions.group.coal":  "Вугілля",
+	"emissions.group.mazut": "Мазут",
+	"emissions.group.gas":   "Природний газ",
+	"emissions.quantity":    "Кількість палива",
+	"emissions.total":       "Викиди від %s",
+	"emissions.factor":      "Показник емісії",
+	"emissions.gross":       "Валовий викид",
+	"emissions.pollutant":   "Речовина",
+	"emissions.burner":      "Пальники",
+
+	"pollutant.particulates": "Тверді частинки",
+	"pollutant.so2":          "Оксиди сірки (SO₂)",
+	"pollutant.nox":          "Оксиди азоту (NOx)",
+	"pollutant.co2":          "Діоксид вуглецю (CO₂)",
+
+	"emissions.unit":               "Одиниця",
+	"emissions.add_fuel":           "Додати паливо",
+	"emissions.energy":             "Енергія палива",
+	"emissions.sum":                "Разом",
+	"emissions.boiler":             "Тип котла",
+	"emissions.by_fuel":            "За типом палива",
+	"emissions.abatement":          "Газоочистка",
+	"emissions.fly_ash":            "Частка золи у винесенні",
+	"emissions.combustible_in_ash": "Горючі у винесенні, %",
+	"emissions.efficiency":         "Ступінь уловлювання",
+	"emissions.preset":             "з пресету",
+
+	"boiler.chamber_dry": "Камерна топка з твердим шлаковидаленням",
+	"boiler.chamber_wet": "Камерна топка з рідким шлаковидаленням",
+	"boiler.grate":       "Шарова топка",
+	"boiler.fluid_bed":   "Топка з киплячим шаром",
+	"boiler.oil_gas":     "Газомазутний котел",
+
+	"abatement.esp":          "Електрофільтр",
+	"abatement.bag_filter":   "Рукавний фільтр",
+	"abatement.wet_scrubber": "Мокрий скрубер",
+	"abatement.none":         "Без очищення",
+
+	"burner.standard": "Звичайні",
+	"burner.low_nox":  "Малотоксичні",
+	"burner.staged":   "Малотоксичні зі ступеневим спалюванням",
+
+	"catalog.title":   "Довідник палива",
+	"catalog.name":    "Назва",
+	"catalog.type":    "Тип",
+	"catalog.density": "Щільність (кг/м³, газ — кг/нм³)",
+	"catalog.edit":    "Змінити",
+	"catalog.delete":  "Видалити",
+	"catalog.save":    "Додати або змінити марку",
+
+	"ledger.title":    "Журнал викидів",
+	"ledger.plant":    "Електростанція",
+	"ledger.block":    "Енергоблок",
+	"ledger.year":     "Рік",
+	"ledger.month":    "Місяць",
+	"ledger.through":  "По місяць",
+	"ledger.all":      "Усі",
+	"ledger.show":     "Показати",
+	"ledger.ytd":      "Викиди за %d рік (з початку року по %s місяць)",
+	"ledger.by_fuel":  "За паливами",
+	"ledger.by_month": "За місяцями",
+	"ledger.entries":  "Записи",
+	"ledger.period":   "Період",
+	"ledger.empty":    "Записів за цей рік немає.",
+	"ledger.add":      "Додати запис",
+
+	"tax.title":     "Ставки екологічного податку",
+	"tax.heading":   "Екологічний податок за %d рік",
+	"tax.year":      "Рік оподаткування",
+	"tax.rate":      "Ставка",
+	"tax.amount":    "Сума податку",
+	"tax.rate_year": "Застосовано ставки, чинні з %d року.",
+	"tax.note":      "Ставки діють з указаного року до року наступних ставок.",
+	"tax.save":      "Додати або змінити ставки",
+
+	"permit.title":           "Дозволи на викиди",
+	"permit.check":           "Перевірка дозволу",
+	"permit.kind":            "Вид ліміту",
+	"permit.kind.annual":     "Валовий викид, т/рік",
+	"permit.kind.factor":     "Показник емісії, г/ГДж",
+	"permit.value":           "Викид",
+	"permit.limit":           "Ліміт",
+	"permit.share":           "Використано",
+	"permit.status":          "Стан",
+	"permit.status.ok":       "У межах ліміту",
+	"permit.status.warning":  "Наближається до ліміту",
+	"permit.status.exceeded": "Ліміт перевищено",
+	"permit.warning":         "Поріг попередження (частка ліміту)",
+	"permit.save":            "Додати або змінити дозвіл",
+	"permit.empty":           "Дозволів ще немає.",
+
+	"solar.title":             "Розрахунок прибутку",
+	"solar.heading":           "Розрахунок прибутку від сонячних електростанцій",
+	"solar.pc":                "Середньодобова потужність (Pc) у МВт",
+	"solar.delta":             "Похибка прогнозу (%)",
+	"solar.pc_result":         "Середньодобова потужність",
+	"solar.delta_result":      "Похибка прогнозу",
+	"solar.current":           "Поточний прогноз",
+	"solar.improved":          "Покращений прогноз (новий σ)",
+	"solar.energy_share":      "Відсоток енергії",
+	"solar.profit":            "Прибуток",
+	"solar.penalty":           "Штраф",
+	"solar.gain":              "Можна отримати %s прибутку!",
+	"solar.sigma":             "Поточне σ прогнозу, МВт",
+	"solar.new_sigma":         "Покращене σ прогнозу, МВт",
+	"solar.band":              "Допустимий небаланс, % від Pc",
+	"solar.tariff":            "Тариф, грн/кВт·год",
+	"solar.sigma_result":      "Середньоквадратичне відхилення прогнозу",
+	"solar.band_result":       "Допустимий небаланс",
+	"solar.tariff_result":     "Тариф",
+	"solar.distribution":      "Розподіл генерації",
+	"distribution.normal":     "нормальний",
+	"distribution.lognormal":  "логнормальний",
+	"distribution.weibull":    "Вейбулла",
+	"distribution.beta":       "бета",
+	"distribution.empirical":  "емпіричний (ядерна оцінка)",
+	"fit.title":               "Оцінка похибки прогнозу за історією",
+	"fit.heading":             "Оцінка розподілу похибки прогнозу",
+	"fit.hint":                "Таблиця CSV або XLSX з погодинними стовпцями forecast (прогноз, МВт) та actual (факт, МВт). Нічні години без прогнозу й генерації не враховуються.",
+	"fit.hours":               "Враховано годин",
+	"fit.pc":                  "Середня прогнозована потужність (Pc)",
+	"fit.bias":                "Зміщення прогнозу (факт − прогноз)",
+	"fit.sigma":               "Середньоквадратичне відхилення похибки (σ)",
+	"fit.bandwidth":           "Ширина ядра",
+	"fit.hours_within":        "Години в межах допуску",
+	"fit.energy_within":       "Енергія годин у межах допуску",
+	"fit.normal_share":        "Енергія в допуску: нормальний розподіл",
+	"fit.kde_share":           "Енергія в допуску: ядерна оцінка",
+	"hourly.title":            "Погодинний розрахунок",
+	"hourly.heading":          "Погодинний прибуток і штрафи сонячної електростанції",
+	"hourly.hint":             "Задайте прогноз за годинами або лише середньодобову потужність для типової кривої ясного неба. Без σ за годинами воно дорівнює похибці прогнозу від прогнозу години; порожній тариф — 7 грн/кВт·год.",
+	"hourly.hour":             "Година",
+	"hourly.forecast":         "Прогноз, МВт",
+	"hourly.day":              "За добу",
+	"hourly.flat":             "За середньодобовою потужністю",
+	"hourly.flat_unavailable": "недоступно: %s",
+	"monte_carlo.days":        "Моделювання Монте-Карло, діб",
+	"monte_carlo.seed":        "Початкове значення генератора",
+	"monte_carlo.heading":     "Моделювання Монте-Карло",
+	"monte_carlo.summary":     "Змодельовано діб: %d, початкове значення генератора: %d",
+	"monte_carlo.mean":        "Середній дохід за добу",
+	"monte_carlo.loss":        "Ймовірність збитку",
+	"monte_carlo.difference":  "Виграш від покращення",
+	"monte_carlo.note":        "Дохід за добу — прибуток мінус штраф; генерація кожної години вибирається з обраного розподілу. Для виграшу від покращення ймовірність збитку означає, що покращений прогноз дасть менше за поточний.",
+
+	"cable.title":   "Вибір перерізу кабелю",
+	"cable.results": "Результати вибору кабелю",
+	"cable.sm":      "Sm (кВА)",
+	"cable.unom":    "Uном (кВ)",
+	"cable.kz":      "Kз (кА)",
+	"cable.ft":      "tф (с)",
+	"cable.jek":     "jек (А/мм²)",
+	"cable.ct":      "Cт (А·√с/мм²)",
+	"cable.izm":     "Розрахунковий струм",
+	"cable.izm_max": "Післяаварійний струм",
+	"cable.sek":     "Економічний переріз",
+	"cable.smin":    "Мінімальний переріз за термічною стійкістю",
+	"cable.valid":   "Обраний переріз кабелю відповідає вимогам.",
+	"cable.invalid": "Переріз кабелю потрібно збільшити!",
+
+	"sc.title":    "Розрахунок струмів короткого замикання",
+	"sc.results":  "Результати розрахунку КЗ",
+	"sc.unom":     "Uном (кВ)",
+	"sc.sk":       "Sк (МВА)",
+	"sc.xc":       "Xc (Ом)",
+	"sc.xt":       "Xт (Ом)",
+	"sc.sb":       "Sб (МВА)",
+	"sc.x_sum":    "Сумарний опір у точці К1",
+	"sc.ik0":      "Початковий струм КЗ",
+	"sc.xc_pu":    "Xc у в.о.",
+	"sc.xt_pu":    "Xт у в.о.",
+	"sc.x_sum_pu": "Сумарний опір у в.о.",
+	"sc.ik0_pu":   "Початковий струм КЗ у в.о.",
+
+	"rel.title":     "Розрахунок надійності",
+	"rel.pv":        "Навантаження (МВт)",
+	"rel.kp":        "Коефіцієнт використання",
+	"rel.t":         "Час роботи (год)",
+	"rel.equipment": "Тип обладнання",
+	"rel.qo":        "Частота відмов",
+	"rel.tavg":      "Середня тривалість відмови",
+	"rel.ka":        "Коефіцієнт простою",
+	"rel.m_energy":  "Втрати енергії",
+
+	"losses.title":   "Розрахунок втрат електроенергії",
+	"losses.pwt":     "Навантаження (МВт)",
+	"losses.kp":      "Коефіцієнт використання",
+	"losses.t":       "Час роботи в році (год)",
+	"losses.wvt":     "Параметр W (Вт)",
+	"losses.mav":     "Втрати електроенергії автотрансформатора",
+	"losses.m_wvt":   "Математичне очікування втрат електропередачі",
+	"losses.m_total": "Загальні втрати електроенергії",
+
+	"load.title":      "Розрахунок електричних навантажень",
+	"load.count":      "Кількість ЕП",
+	"load.power":      "Потужність (кВт)",
+	"load.voltage":    "Напруга (В)",
+	"load.cos_phi":    "cos φ",
+	"load.eta":        "ККД",
+	"load.util_coeff": "Коефіцієнт використання",
+	"load.tg_phi":     "tg φ",
+	"load.kv":         "Kв",
+	"load.parameter":  "Параметр",
+	"load.value":      "Значення",
+
+	"Ip (Calculation Current in A)":         "Ip (розрахунковий струм, А)",
+	"Kv (Group Usage Coefficient)":          "Kв (груповий коефіцієнт використання)",
+	"Ne (Effective Number of EP)":           "nе (ефективна кількість ЕП)",
+	"Kr (Calculated Power Coefficient)":     "Kр (коефіцієнт розрахункового навантаження)",
+	"Pp (Calculated Active Load in kW)":     "Pр (розрахункове активне навантаження, кВт)",
+	"Qp (Calculated Reactive Load in kVAr)": "Qр (розрахункове реактивне навантаження, квар)",
+	"Sp (Total Power in kVA)":               "Sр (повна потужність, кВА)",
+	"Ig (Calculated Group Current in A)":    "Iг (груповий розрахунковий струм, А)",
+
+	"index.title":       "Енергетичні калькулятори",
+	"index.fuel":        "Склад палива (робоча, суха та горюча маса)",
+	"index.bases":       "Склад палива на всі маси",
+	"index.mazut":       "Перерахунок складу мазуту",
+	"index.emissions":   "Викиди твердих частинок",
+	"index.solar":       "Прибуток сонячної електростанції",
+	"index.cable":       "Вибір перерізу кабелю",
+	"index.short":       "Струми короткого замикання",
+	"index.reliability": "Надійність схеми електропостачання",
+	"index.losses":      "Втрати електроенергії",
+	"index.load":        "Електричні навантаження",
+
+	"value is required":                                             "потрібно вказати значення",
+	"must be a number":                                              "має бути числом",
+	"must be a whole number":                                        "має бути цілим числом",
+	"must be greater than zero":                                     "має бути більше нуля",
+	"must not be negative":                                          "не може бути від'ємним",
+	"must be between %g and %g":                                     "має бути від %g до %g",
+	"must be greater than 0 and at most 1":                          "має бути більше 0 і не більше 1",
+	"ash and pyritic sulfur together must be less than 100%%":       "зола й колчеданна сірка разом мають бути менше 100%%",
+	"pyritic sulfur must not exceed total sulfur":                   "колчеданна сірка не може перевищувати загальну",
+	"must be working, dry, daf or organic":                          "має бути робоча, суха, горюча або органічна маса",
+	"moisture and ash together must be less than 100%%":             "волога й зола разом мають бути менше 100%%",
+	"components must sum to 100%%, got %.2f%%":                      "сума компонентів має дорівнювати 100%%, отримано %.2f%%",
+	"the file is too large":                                         "файл завеликий",
+	"the table has no header row":                                   "таблиця не містить рядка заголовка",
+	"the table has too many rows":                                   "таблиця містить забагато рядків",
+	"the workbook has no sheets":                                    "книга не містить аркушів",
+	"unknown distribution":                                          "невідомий розподіл",
+	"choose a file to upload":                                       "оберіть файл для завантаження",
+	"at least two hours with generation are required":               "потрібні щонайменше дві години з генерацією",
+	"forecast errors must vary and the forecast must be positive":   "похибки прогнозу мають різнитися, а прогноз — бути додатним",
+	"the table must have forecast and actual columns":               "таблиця має містити стовпці forecast та actual",
+	"at least two points are required":                              "потрібні щонайменше дві точки",
+	"must have %d hourly values":                                    "має містити %d погодинних значень",
+	"must have one or %d hourly values":                             "має містити одне або %d погодинних значень",
+	"the distribution cannot have this mean and standard deviation": "розподіл не може мати такі середнє та відхилення",
+	"combustible composition must sum to 100%%, residual %.2f%%":    "сума складу горючої маси має дорівнювати 100%%, нев'язка %.2f%%",
+	"no tax rates for the year":                                     "немає ставок податку для цього року",
+	"permit not found":                                              "дозвіл не знайдено",
+	"unknown pollutant":                                             "невідома забруднююча речовина",
+	"duplicate pollutant":                                           "речовину вказано двічі",
+	"at least one limit is required":                                "потрібно задати хоча б один ліміт",
+	"ledger entry not found":                                        "запис журналу не знайдено",
+	"unknown fuel type":                                             "невідомий тип палива",
+	"unknown equipment type":                                        "невідомий тип обладнання",
+	"total impedance Xc + Xt must be greater than zero":             "сумарний опір Xc + Xт має бути більше нуля",
+	"unknown quantity unit":                                         "невідома одиниця кількості",
+	"fuel density is not set, volume cannot be converted":           "щільність палива не задана, об'єм неможливо перерахувати",
+	"at least one fuel is required":                                 "потрібно вказати хоча б одне паливо",
+	"unknown boiler type":                                           "невідомий тип котла",
+	"unknown abatement equipment":                                   "невідоме газоочисне обладнання",
+	"unknown burner type":                                           "невідомий тип пальників",
+	"components must not exceed 100%%":                              "сума компонентів не може перевищувати 100%%",
+	"must be coal, mazut or gas":                                    "має бути coal, mazut або gas",
+	"method is not allowed":                                         "метод не підтримується",
+	"only POST method is supported":                                 "підтримується лише метод POST",
+	"unknown calculator":                                            "невідомий калькулятор",
+	"input validation failed":                                       "вхідні дані не пройшли перевірку",
+}
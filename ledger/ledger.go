@@ -0,0 +1,369 @@
+// Пакет ledger веде журнал місячного споживання палива енергоблоками
+// електростанцій і підсумовує викиди з початку року для річної
+// екологічної декларації.
+package ledger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"Go_tutor/emissions"
+	"Go_tutor/fsutil"
+	"Go_tutor/tax"
+	"Go_tutor/validate"
+)
+
+// ErrNotFound повертається для запису, якого немає в журналі.
+var ErrNotFound = errors.New("ledger entry not found")
+
+// Entry — споживання одного палива енергоблоком за місяць. Викиди
+// розраховуються під час запису й зберігаються разом із ним, тож подальші
+// зміни довідника палива не змінюють уже внесених даних.
+type Entry struct {
+	ID    int    `json:"id"`
+	Plant string `json:"plant"`           // Електростанція
+	Block string `json:"block,omitempty"` // Енергоблок
+	Year  int    `json:"year"`
+	Month int    `json:"month"` // 1–12
+	emissions.Line
+	emissions.Equipment
+
+	Result emissions.Result `json:"result"` // Розраховані викиди
+}
+
+// Validate перевіряє запис перед розрахунком викидів.
+func (e Entry) Validate() error {
+	errs := validate.Errors{}
+	if strings.TrimSpace(e.Plant) == "" {
+		errs.Add("plant", "value is required")
+	}
+	errs.Range("year", float64(e.Year), 1990, 2100)
+	errs.Range("month", float64(e.Month), 1, 12)
+	return errs.Err()
+}
+
+// Filter відбирає записи журналу. Порожні поля не обмежують вибірку.
+type Filter struct {
+	Plant string
+	Block string
+	Year  int
+	Month int
+}
+
+func (f Filter) match(e Entry) bool {
+	return (f.Plant == "" || e.Plant == f.Plant) &&
+		(f.Block == "" || e.Block == f.Block) &&
+		(f.Year == 0 || e.Year == f.Year) &&
+		(f.Month == 0 || e.Month == f.Month)
+}
+
+// FuelTotals — сумарні викиди від одного палива.
+type FuelTotals struct {
+	Fuel string `json:"fuel"`
+	emissions.Totals
+}
+
+// MonthTotals — сумарні викиди за місяць.
+type MonthTotals struct {
+	Month int `json:"month"`
+	emissions.Totals
+}
+
+// Summary — викиди з початку року по місяць Through включно.
+type Summary struct {
+	Year    int              `json:"year"`
+	Through int              `json:"through"`
+	Total   emissions.Totals `json:"total"`
+	ByFuel  []FuelTotals     `json:"by_fuel"`
+	ByMonth []MonthTotals    `json:"by_month"`
+	Checks  []Check          `json:"checks,omitempty"` // Дотримання дозволу, якщо відібрано станцію з дозволом
+	Tax     *tax.Tax         `json:"tax,omitempty"`    // Екологічний податок, якщо для року є ставки
+}
+
+// Store — журнал, безпечний для одночасного використання. Після Open
+// кожна зміна зберігається у файл.
+type Store struct {
+	mu      sync.RWMutex
+	entries []Entry
+	permits []Permit
+	nextID  int
+	path    string
+}
+
+// Вміст файлу журналу
+type file struct {
+	Entries []Entry  `json:"entries"`
+	Permits []Permit `json:"permits"`
+}
+
+// Default — журнал, з яким працюють веб-сторінка та API.
+var Default = NewStore()
+
+// NewStore створює порожній журнал у пам'яті.
+func NewStore() *Store {
+	return &Store{nextID: 1}
+}
+
+// Open прив'язує журнал до JSON-файлу. Наявний файл завантажується, а
+// якщо його немає, у нього записується поточний вміст журналу. Файл, що
+// містить лише масив записів, теж приймається.
+func (s *Store) Open(path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.path = path
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s.save(s.entries, s.permits)
+	}
+	if err != nil {
+		return err
+	}
+
+	var f file
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		err = json.Unmarshal(data, &f.Entries)
+	} else {
+		err = json.Unmarshal(data, &f)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	for i, p := range f.Permits {
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("%s: permit %d (%q): %w", path, i+1, p.Plant, err)
+		}
+	}
+	sortPermits(f.Permits)
+	s.entries, s.permits, s.nextID = f.Entries, f.Permits, 1
+	for _, e := range s.entries {
+		if e.ID >= s.nextID {
+			s.nextID = e.ID + 1
+		}
+	}
+	return nil
+}
+
+// Add розраховує викиди для нового запису й додає його до журналу.
+func (s *Store) Add(e Entry) (Entry, error) {
+	if err := calculate(&e); err != nil {
+		return Entry{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e.ID = s.nextID
+	if err := s.save(append(append([]Entry(nil), s.entries...), e), s.permits); err != nil {
+		return Entry{}, err
+	}
+	s.entries = append(s.entries, e)
+	s.nextID++
+	return e, nil
+}
+
+// Update замінює запис id і перераховує його викиди.
+func (s *Store) Update(id int, e Entry) (Entry, error) {
+	if err := calculate(&e); err != nil {
+		return Entry{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.index(id)
+	if i < 0 {
+		return Entry{}, ErrNotFound
+	}
+	e.ID = id
+	entries := append([]Entry(nil), s.entries...)
+	entries[i] = e
+	if err := s.save(entries, s.permits); err != nil {
+		return Entry{}, err
+	}
+	s.entries = entries
+	return e, nil
+}
+
+// Delete видаляє запис id.
+func (s *Store) Delete(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.index(id)
+	if i < 0 {
+		return ErrNotFound
+	}
+	entries := append(append([]Entry(nil), s.entries[:i]...), s.entries[i+1:]...)
+	if err := s.save(entries, s.permits); err != nil {
+		return err
+	}
+	s.entries = entries
+	return nil
+}
+
+// Get повертає запис за ідентифікатором.
+func (s *Store) Get(id int) (Entry, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	i := s.index(id)
+	if i < 0 {
+		return Entry{}, false
+	}
+	return s.entries[i], true
+}
+
+// List повертає відібрані записи, впорядковані за періодом, станцією та
+// енергоблоком.
+func (s *Store) List(f Filter) []Entry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var out []Entry
+	for _, e := range s.entries {
+		if f.match(e) {
+			out = append(out, e)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.Year != b.Year {
+			return a.Year < b.Year
+		}
+		if a.Month != b.Month {
+			return a.Month < b.Month
+		}
+		if a.Plant != b.Plant {
+			return a.Plant < b.Plant
+		}
+		return a.Block < b.Block
+	})
+	return out
+}
+
+// Plants повертає назви електростанцій із записів журналу та дозволів в
+// алфавітному порядку.
+func (s *Store) Plants() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	seen := map[string]bool{}
+	var out []string
+	for _, e := range s.entries {
+		if !seen[e.Plant] {
+			seen[e.Plant] = true
+			out = append(out, e.Plant)
+		}
+	}
+	for _, p := range s.permits {
+		if !seen[p.Plant] {
+			seen[p.Plant] = true
+			out = append(out, p.Plant)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Summary підсумовує викиди за рік f.Year з початку року по місяць f.Month
+// включно (за весь рік, якщо місяць не задано) окремо за паливами й
+// місяцями. Для станції з дозволом підсумок порівнюється з лімітами, а за
+// наявності ставок розраховується екологічний податок.
+func (s *Store) Summary(f Filter) Summary {
+	sum := s.summarize(f)
+	if p, ok := s.Permit(f.Plant); ok {
+		sum.Checks = p.Check(sum.Total, sum.Total)
+	}
+	if t, err := tax.Default.Calculate(f.Year, sum.Total); err == nil {
+		sum.Tax = &t
+	}
+	return sum
+}
+
+func (s *Store) summarize(f Filter) Summary {
+	through := f.Month
+	if through == 0 {
+		through = 12
+	}
+	sum := Summary{Year: f.Year, Through: through, ByFuel: []FuelTotals{}, ByMonth: []MonthTotals{}}
+
+	f.Month = 0
+	fuels := map[string]int{}
+	months := map[int]int{}
+	for _, e := range s.List(f) {
+		if e.Month > through {
+			continue
+		}
+		sum.Total.Add(e.Result)
+
+		i, ok := fuels[e.Fuel]
+		if !ok {
+			i = len(sum.ByFuel)
+			fuels[e.Fuel] = i
+			sum.ByFuel = append(sum.ByFuel, FuelTotals{Fuel: e.Fuel})
+		}
+		sum.ByFuel[i].Add(e.Result)
+
+		i, ok = months[e.Month]
+		if !ok {
+			i = len(sum.ByMonth)
+			months[e.Month] = i
+			sum.ByMonth = append(sum.ByMonth, MonthTotals{Month: e.Month})
+		}
+		sum.ByMonth[i].Add(e.Result)
+	}
+	return sum
+}
+
+// calculate перевіряє запис і розраховує його викиди.
+func calculate(e *Entry) error {
+	e.Plant, e.Block = strings.TrimSpace(e.Plant), strings.TrimSpace(e.Block)
+	errs := validate.Errors{}
+	if err := e.Validate(); err != nil {
+		errs, _ = validate.Fields(err)
+	}
+	res, err := emissions.Calculate(emissions.Input{Line: e.Line, Equipment: e.Equipment})
+	if fields, ok := validate.Fields(err); ok {
+		for field, m := range fields {
+			errs.Add(field, m.Format, m.Args...)
+		}
+	} else if err != nil {
+		return err
+	}
+	if err := errs.Err(); err != nil {
+		return err
+	}
+	e.Result = res
+	return nil
+}
+
+func (s *Store) index(id int) int {
+	for i, e := range s.entries {
+		if e.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func sortPermits(permits []Permit) {
+	sort.Slice(permits, func(i, j int) bool { return permits[i].Plant < permits[j].Plant })
+}
+
+// save атомарно записує записи й дозволи у файл журналу, якщо його задано.
+func (s *Store) save(entries []Entry, permits []Permit) error {
+	if s.path == "" {
+		return nil
+	}
+	f := file{Entries: entries, Permits: permits}
+	if f.Entries == nil {
+		f.Entries = []Entry{}
+	}
+	if f.Permits == nil {
+		f.Permits = []Permit{}
+	}
+	return fsutil.WriteFileAtomic(s.path, func(w io.Writer) error {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(f)
+	})
+}
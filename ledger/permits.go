@@ -0,0 +1,222 @@
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"Go_tutor/emissions"
+	"Go_tutor/validate"
+)
+
+// ErrNoPermit повертається для електростанції без дозволу на викиди.
+var ErrNoPermit = errors.New("permit not found")
+
+// Частка ліміту, з якої видається попередження, якщо в дозволі не задано
+// іншої
+const DefaultWarning = 0.9
+
+// Стан дотримання ліміту
+const (
+	StatusOK       = "ok"       // Нижче порогу попередження
+	StatusWarning  = "warning"  // Наближається до ліміту
+	StatusExceeded = "exceeded" // Ліміт перевищено
+)
+
+// Види лімітів
+const (
+	LimitAnnual = "annual" // Валовий викид за рік (т)
+	LimitFactor = "factor" // Показник емісії (г/ГДж)
+)
+
+// Limit — ліміти викиду однієї речовини. Нульовий ліміт не перевіряється.
+type Limit struct {
+	Pollutant string  `json:"pollutant"`
+	Annual    float64 `json:"annual,omitempty"` // Валовий викид (т/рік)
+	Factor    float64 `json:"factor,omitempty"` // Показник емісії (г/ГДж)
+}
+
+// Permit — дозвіл на викиди електростанції.
+type Permit struct {
+	Plant   string  `json:"plant"`
+	Warning float64 `json:"warning,omitempty"` // Частка ліміту для попередження, за замовчуванням DefaultWarning
+	Limits  []Limit `json:"limits"`
+}
+
+// Check — порівняння викиду з лімітом дозволу.
+type Check struct {
+	Pollutant string  `json:"pollutant"`
+	Kind      string  `json:"kind"`  // annual або factor
+	Value     float64 `json:"value"` // Викид (т або г/ГДж)
+	Limit     float64 `json:"limit"`
+	Share     float64 `json:"share"` // Частка використаного ліміту
+	Status    string  `json:"status"`
+}
+
+// Validate перевіряє дозвіл перед збереженням.
+func (p Permit) Validate() error {
+	errs := validate.Errors{}
+	if strings.TrimSpace(p.Plant) == "" {
+		errs.Add("plant", "value is required")
+	}
+	if p.Warning != 0 {
+		errs.Fraction("warning", p.Warning)
+	}
+	seen := map[string]bool{}
+	for i, l := range p.Limits {
+		field := func(name string) string { return fmt.Sprintf("limits.%d.%s", i, name) }
+		switch l.Pollutant {
+		case emissions.PollutantParticulates, emissions.PollutantSO2, emissions.PollutantNOx, emissions.PollutantCO2:
+			if seen[l.Pollutant] {
+				errs.Add(field("pollutant"), "duplicate pollutant")
+			}
+			seen[l.Pollutant] = true
+		default:
+			errs.Add(field("pollutant"), "unknown pollutant")
+		}
+		errs.NonNegative(field("annual"), l.Annual)
+		errs.NonNegative(field("factor"), l.Factor)
+		if l.Annual == 0 && l.Factor == 0 {
+			errs.Add(field("annual"), "at least one limit is required")
+		}
+	}
+	return errs.Err()
+}
+
+// Limit повертає ліміти речовини pollutant; якщо їх не задано — нульові.
+func (p Permit) Limit(pollutant string) Limit {
+	for _, l := range p.Limits {
+		if l.Pollutant == pollutant {
+			return l
+		}
+	}
+	return Limit{Pollutant: pollutant}
+}
+
+// Check порівнює показники емісії calc з лімітами г/ГДж, а валові викиди
+// total — з річними лімітами.
+func (p Permit) Check(calc, total emissions.Totals) []Check {
+	warning := p.Warning
+	if warning == 0 {
+		warning = DefaultWarning
+	}
+	var out []Check
+	for _, pollutant := range emissions.Pollutants() {
+		l := p.Limit(pollutant)
+		if l.Factor > 0 {
+			out = append(out, check(pollutant, LimitFactor, calc.Pollutant(pollutant).Factor, l.Factor, warning))
+		}
+		if l.Annual > 0 {
+			out = append(out, check(pollutant, LimitAnnual, total.Pollutant(pollutant).Total, l.Annual, warning))
+		}
+	}
+	return out
+}
+
+// Percent повертає частку використаного ліміту у відсотках.
+func (c Check) Percent() float64 {
+	return c.Share * 100
+}
+
+func check(pollutant, kind string, value, limit, warning float64) Check {
+	c := Check{Pollutant: pollutant, Kind: kind, Value: value, Limit: limit, Share: value / limit, Status: StatusOK}
+	if c.Share > 1 {
+		c.Status = StatusExceeded
+	} else if c.Share >= warning {
+		c.Status = StatusWarning
+	}
+	return c
+}
+
+// Permits повертає дозволи в алфавітному порядку електростанцій.
+func (s *Store) Permits() []Permit {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]Permit(nil), s.permits...)
+}
+
+// Permit повертає дозвіл електростанції.
+func (s *Store) Permit(plant string) (Permit, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	i := s.permitIndex(plant)
+	if i < 0 {
+		return Permit{}, false
+	}
+	return s.permits[i], true
+}
+
+// PutPermit додає або замінює дозвіл електростанції. Повертає true, якщо
+// дозвіл додано.
+func (s *Store) PutPermit(p Permit) (bool, error) {
+	p.Plant = strings.TrimSpace(p.Plant)
+	if err := p.Validate(); err != nil {
+		return false, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	permits := append([]Permit(nil), s.permits...)
+	i := s.permitIndex(p.Plant)
+	if i < 0 {
+		permits = append(permits, p)
+		sortPermits(permits)
+	} else {
+		permits[i] = p
+	}
+	if err := s.save(s.entries, permits); err != nil {
+		return false, err
+	}
+	s.permits = permits
+	return i < 0, nil
+}
+
+// DeletePermit видаляє дозвіл електростанції.
+func (s *Store) DeletePermit(plant string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.permitIndex(plant)
+	if i < 0 {
+		return ErrNoPermit
+	}
+	permits := append(append([]Permit(nil), s.permits[:i]...), s.permits[i+1:]...)
+	if err := s.save(s.entries, permits); err != nil {
+		return err
+	}
+	s.permits = permits
+	return nil
+}
+
+// Check перевіряє дозвіл електростанції plant для нового розрахунку calc:
+// його показники емісії та валові викиди за рік year разом із уже
+// внесеними до журналу.
+func (s *Store) Check(plant string, year int, calc emissions.Totals) ([]Check, error) {
+	p, ok := s.Permit(plant)
+	if !ok {
+		return nil, ErrNoPermit
+	}
+	total := s.summarize(Filter{Plant: plant, Year: year}).Total
+	total.Merge(calc)
+	return p.Check(calc, total), nil
+}
+
+// CheckEntry перевіряє дозвіл після внесення запису e: показники емісії
+// запису та валові викиди з початку року по місяць запису. Для станції без
+// дозволу повертає nil.
+func (s *Store) CheckEntry(e Entry) []Check {
+	p, ok := s.Permit(e.Plant)
+	if !ok {
+		return nil
+	}
+	var calc emissions.Totals
+	calc.Add(e.Result)
+	return p.Check(calc, s.summarize(Filter{Plant: e.Plant, Year: e.Year, Month: e.Month}).Total)
+}
+
+func (s *Store) permitIndex(plant string) int {
+	for i, p := range s.permits {
+		if p.Plant == plant {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,73 @@
+// Пакет load розраховує електричні навантаження групи електроприймачів.
+package load
+
+import (
+	"math"
+
+	"Go_tutor/validate"
+)
+
+type Input struct {
+	Name      string  `json:"name"`
+	Eta       float64 `json:"eta"`
+	CosPhi    float64 `json:"cos_phi"`
+	Voltage   float64 `json:"voltage"`
+	Count     int     `json:"count"`
+	Power     float64 `json:"power"`
+	UtilCoeff float64 `json:"util_coeff"`
+	TgPhi     float64 `json:"tg_phi"`
+	Kv        float64 `json:"kv"`
+}
+
+type Result struct {
+	Ip float64 `json:"Ip (Calculation Current in A)"`
+	Kv float64 `json:"Kv (Group Usage Coefficient)"`
+	Ne float64 `json:"Ne (Effective Number of EP)"`
+	Kr float64 `json:"Kr (Calculated Power Coefficient)"`
+	Pp float64 `json:"Pp (Calculated Active Load in kW)"`
+	Qp float64 `json:"Qp (Calculated Reactive Load in kVAr)"`
+	Sp float64 `json:"Sp (Total Power in kVA)"`
+	Ig float64 `json:"Ig (Calculated Group Current in A)"`
+}
+
+// Validate перевіряє вхідні дані.
+func (data Input) Validate() error {
+	errs := validate.Errors{}
+	errs.Fraction("eta", data.Eta)
+	errs.Fraction("cos_phi", data.CosPhi)
+	errs.Positive("voltage", data.Voltage)
+	errs.Positive("count", float64(data.Count))
+	errs.Positive("power", data.Power)
+	errs.Range("util_coeff", data.UtilCoeff, 0, 1)
+	errs.NonNegative("tg_phi", data.TgPhi)
+	errs.NonNegative("kv", data.Kv)
+	return errs.Err()
+}
+
+// Calculate розраховує навантаження групи однакових електроприймачів.
+func Calculate(data Input) (Result, error) {
+	if err := data.Validate(); err != nil {
+		return Result{}, err
+	}
+
+	pTotal := float64(data.Count) * data.Power
+	ip := (1000 * pTotal) / (math.Sqrt(3) * data.Voltage * data.CosPhi * data.Eta)
+	kvGroup := data.Kv
+	ne := math.Pow(float64(data.Count)*data.Power, 2) / (float64(data.Count) * math.Pow(data.Power, 2))
+	kr := 1.25
+	pp := kr * kvGroup * pTotal
+	qp := kr * kvGroup * pTotal * data.TgPhi
+	sp := math.Sqrt(pp*pp + qp*qp)
+	ig := (1000 * pp) / (math.Sqrt(3) * data.Voltage)
+
+	return Result{
+		Ip: ip,
+		Kv: kvGroup,
+		Ne: ne,
+		Kr: kr,
+		Pp: pp,
+		Qp: qp,
+		Sp: sp,
+		Ig: ig,
+	}, nil
+}
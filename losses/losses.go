@@ -0,0 +1,43 @@
+// Пакет losses розраховує втрати електроенергії автотрансформатора та
+// лінії електропередачі.
+package losses
+
+import "Go_tutor/validate"
+
+// Вхідні дані
+type Input struct {
+	Pwt float64 `json:"pwt"` // Навантаження (МВт)
+	Kp  float64 `json:"kp"`  // Коефіцієнт використання
+	T   int     `json:"t"`   // Час роботи в році (год)
+	Wvt float64 `json:"wvt"` // Параметр W (Вт)
+}
+
+// Результати, кВт·год
+type Result struct {
+	Mav    float64 `json:"mav"`     // Втрати автотрансформатора
+	MWvt   float64 `json:"m_wvt"`   // Математичне очікування втрат електропередачі
+	Mtotal float64 `json:"m_total"` // Загальні втрати
+}
+
+// Validate перевіряє вхідні дані. Навантаження, коефіцієнт використання
+// й час роботи обов'язкові: без них загальні втрати стають від'ємними.
+func (in Input) Validate() error {
+	errs := validate.Errors{}
+	errs.Positive("pwt", in.Pwt)
+	errs.Fraction("kp", in.Kp)
+	errs.Positive("t", float64(in.T))
+	errs.NonNegative("wvt", in.Wvt)
+	return errs.Err()
+}
+
+// Calculate розраховує втрати електроенергії.
+func Calculate(in Input) (Result, error) {
+	if err := in.Validate(); err != nil {
+		return Result{}, err
+	}
+
+	Mav := in.Kp * in.Pwt * float64(in.T)                  // Втрати автотрансформатора
+	MWvt := in.Kp * float64(in.T) * 4e-3 * 5.12e2 * in.Wvt // Математичне очікування втрат
+	Mtotal := (23.6 * Mav) + (17.6 * MWvt) - 2682000       // Загальні втрати
+	return Result{Mav, MWvt, Mtotal}, nil
+}
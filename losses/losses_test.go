@@ -0,0 +1,39 @@
+package losses
+
+import (
+	"math"
+	"testing"
+
+	"Go_tutor/validate"
+)
+
+func TestCalculateRejectsEmptyInput(t *testing.T) {
+	_, err := Calculate(Input{})
+	errs, ok := validate.Fields(err)
+	if !ok {
+		t.Fatalf("Calculate(Input{}) error = %v", err)
+	}
+	for _, field := range []string{"pwt", "kp", "t"} {
+		if _, ok := errs[field]; !ok {
+			t.Errorf("no error for %s", field)
+		}
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	res, err := Calculate(Input{Pwt: 5120, Kp: 0.01, T: 6451, Wvt: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Result{Mav: 330291.2, MWvt: 132.11648}
+	want.Mtotal = 23.6*want.Mav + 17.6*want.MWvt - 2682000
+	for name, got := range map[string][2]float64{
+		"mav":     {res.Mav, want.Mav},
+		"m_wvt":   {res.MWvt, want.MWvt},
+		"m_total": {res.Mtotal, want.Mtotal},
+	} {
+		if math.Abs(got[0]-got[1]) > 1e-6*math.Abs(got[1]) {
+			t.Errorf("%s = %g, want %g", name, got[0], got[1])
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package number
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// DefaultPrecision застосовується до полів без окремих налаштувань.
+const DefaultPrecision = 2
+
+// MaxPrecision обмежує точність, яку можна задати в запиті.
+const MaxPrecision = 10
+
+// Spec задає точність і одиницю виміру для поля результату.
+type Spec struct {
+	Precision int
+	Unit      string
+}
+
+// Specs — налаштування форматування за назвою поля.
+type Specs map[string]Spec
+
+// Formatter форматує поля результатів для шаблонів.
+type Formatter struct {
+	Locale Locale
+	Specs  Specs
+	// Precision, якщо не від'ємна, замінює точність усіх полів.
+	Precision int
+}
+
+// NewFormatter створює форматувальник із точністю з параметра запиту
+// precision, якщо його задано.
+func NewFormatter(r *http.Request, locale Locale, specs Specs) Formatter {
+	f := Formatter{Locale: locale, Specs: specs, Precision: -1}
+	if p, err := strconv.Atoi(r.URL.Query().Get("precision")); err == nil && p >= 0 && p <= MaxPrecision {
+		f.Precision = p
+	}
+	return f
+}
+
+// Format виводить значення поля з його точністю та одиницею виміру.
+func (f Formatter) Format(field string, v float64) string {
+	spec, ok := f.Specs[field]
+	if !ok {
+		spec.Precision = DefaultPrecision
+	}
+	if f.Precision >= 0 {
+		spec.Precision = f.Precision
+	}
+
+	s := f.Locale.Format(v, spec.Precision)
+	if spec.Unit != "" {
+		s += " " + spec.Unit
+	}
+	return s
+}
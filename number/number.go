@@ -0,0 +1,115 @@
+// Пакет number розбирає числа, введені з комою або крапкою як десятковим
+// роздільником, та форматує результати з потрібною точністю й одиницями.
+package number
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrSyntax повертається для рядків, які не є числом.
+var ErrSyntax = errors.New("invalid number")
+
+// Locale описує роздільники, якими число виводиться користувачу.
+type Locale struct {
+	Decimal   string
+	Thousands string
+}
+
+var (
+	Ukrainian = Locale{Decimal: ",", Thousands: " "}
+	English   = Locale{Decimal: ".", Thousands: ","}
+)
+
+// Parse розбирає число з десятковою комою або крапкою та необов'язковими
+// роздільниками тисяч (пробіл, апостроф, кома чи крапка). Якщо трапляються
+// обидва знаки, десятковим вважається останній. Одна кома без крапки —
+// завжди десяткова кома, навіть коли за нею рівно три цифри: "1,234" — це
+// 1,234, а не 1234, бо користувачі форм пишуть десяткові дроби з комою.
+// Число з групами тисяч через кому однозначне, лише якщо груп кілька
+// ("1,234,567") або є десяткова крапка ("1,234.5"). Роздільник у кінці
+// числа ("12," чи "12.") — помилка.
+func Parse(s string) (float64, error) {
+	s = strings.NewReplacer("\u00a0", " ", "\u202f", " ", "'", " ", "’", " ").Replace(strings.TrimSpace(s))
+
+	commas, dots := strings.Count(s, ","), strings.Count(s, ".")
+	decimal, thousands := "", ""
+	switch {
+	case commas > 0 && dots > 0:
+		if strings.LastIndex(s, ",") > strings.LastIndex(s, ".") {
+			decimal, thousands = ",", "."
+		} else {
+			decimal, thousands = ".", ","
+		}
+	case commas > 1:
+		thousands = ","
+	case dots > 1:
+		thousands = "."
+	case commas == 1:
+		decimal = ","
+	case dots == 1:
+		decimal = "."
+	}
+
+	intPart, fracPart := s, ""
+	if decimal != "" {
+		i := strings.LastIndex(s, decimal)
+		intPart, fracPart = s[:i], s[i+1:]
+		if fracPart == "" || strings.ContainsAny(fracPart, " ,.") {
+			return 0, ErrSyntax
+		}
+	}
+	if thousands != "" {
+		intPart = strings.ReplaceAll(intPart, thousands, " ")
+	}
+	if strings.Contains(intPart, " ") {
+		sign := ""
+		if strings.HasPrefix(intPart, "-") || strings.HasPrefix(intPart, "+") {
+			sign, intPart = intPart[:1], intPart[1:]
+		}
+		groups := strings.Split(intPart, " ")
+		for i, g := range groups {
+			if (i == 0 && (len(g) < 1 || len(g) > 3)) || (i > 0 && len(g) != 3) {
+				return 0, ErrSyntax
+			}
+		}
+		intPart = sign + strings.Join(groups, "")
+	}
+
+	if decimal != "" {
+		intPart += "." + fracPart
+	}
+	v, err := strconv.ParseFloat(intPart, 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, ErrSyntax
+	}
+	return v, nil
+}
+
+// Format виводить число з precision знаками після коми і групуванням тисяч.
+func (l Locale) Format(v float64, precision int) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return strconv.FormatFloat(v, 'f', precision, 64)
+	}
+
+	s := strconv.FormatFloat(math.Abs(v), 'f', precision, 64)
+	intPart, fracPart, _ := strings.Cut(s, ".")
+
+	var b strings.Builder
+	if v < 0 && strings.Trim(s, "0.") != "" {
+		b.WriteByte('-')
+	}
+	for i, digit := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteString(l.Thousands)
+		}
+		b.WriteRune(digit)
+	}
+	if fracPart != "" {
+		b.WriteString(l.Decimal)
+		b.WriteString(fracPart)
+	}
+	return b.String()
+}
@@ -0,0 +1,102 @@
+package number
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+	}{
+		{"0,7", 0.7},
+		{"0.7", 0.7},
+		{"-12,5", -12.5},
+		{",5", 0.5},
+		{"1 234,5", 1234.5},
+		{"1\u00a0234,5", 1234.5},
+		{"1'234.5", 1234.5},
+		{"1.234,5", 1234.5},
+		{"1,234.5", 1234.5},
+		{"1,234,567", 1234567},
+		{"1.234.567", 1234567},
+		{"1 234 567", 1234567},
+		// Одна кома — десяткова, навіть з трьома цифрами після неї
+		{"1,234", 1.234},
+		{"1.234", 1.234},
+		{"+3", 3},
+		{"2e3", 2000},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("Parse(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, in := range []string{
+		"", "abc", ",", ".", "12,", "12.", "1,234,", "1.234.", "1,2,3",
+		"12 34", "1,23.4,5", "1.234,5.6", "NaN", "Inf", "1e400",
+	} {
+		if v, err := Parse(in); err == nil {
+			t.Errorf("Parse(%q) = %v, want error", in, v)
+		}
+	}
+}
+
+func TestLocaleFormat(t *testing.T) {
+	tests := []struct {
+		locale    Locale
+		v         float64
+		precision int
+		want      string
+	}{
+		{Ukrainian, 1234567.891, 2, "1\u00a0234\u00a0567,89"},
+		{English, 1234567.891, 2, "1,234,567.89"},
+		{English, 21.345678901, 3, "21.346"},
+		{Ukrainian, 999.996, 2, "1\u00a0000,00"},
+		{Ukrainian, 42, 0, "42"},
+		{Ukrainian, -0.001, 2, "0,00"},
+		{English, -1234.5, 1, "-1,234.5"},
+	}
+	for _, tt := range tests {
+		if got := tt.locale.Format(tt.v, tt.precision); got != tt.want {
+			t.Errorf("Format(%v, %d) = %q, want %q", tt.v, tt.precision, got, tt.want)
+		}
+	}
+}
+
+func TestFormatterPrecision(t *testing.T) {
+	specs := Specs{
+		"q":    {Precision: 3, Unit: "MJ/kg"},
+		"rate": {Precision: 1},
+	}
+	tests := []struct {
+		query string
+		field string
+		want  string
+	}{
+		// Точність і одиниця з налаштувань поля
+		{"", "q", "21.346\u00a0MJ/kg"},
+		{"", "rate", "21.3"},
+		// Поле без налаштувань
+		{"", "other", "21.35"},
+		// Параметр precision замінює точність усіх полів
+		{"?precision=5", "q", "21.34568\u00a0MJ/kg"},
+		{"?precision=0", "other", "21"},
+		// Неприпустима точність ігнорується
+		{"?precision=11", "q", "21.346\u00a0MJ/kg"},
+		{"?precision=-1", "rate", "21.3"},
+		{"?precision=x", "rate", "21.3"},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", "/"+tt.query, nil)
+		f := NewFormatter(r, English, specs)
+		if got := f.Format(tt.field, 21.345678901); got != tt.want {
+			t.Errorf("%s: Format(%q) = %q, want %q", tt.query, tt.field, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// Пакет reliability оцінює надійність елементів схеми електропостачання та
+// очікуваний недовідпуск енергії.
+package reliability
+
+import (
+	"errors"
+	"sort"
+
+	"Go_tutor/validate"
+)
+
+// ErrUnknownEquipment повертається для обладнання, якого немає в довіднику.
+var ErrUnknownEquipment = errors.New("unknown equipment type")
+
+type Equipment struct {
+	Name  string
+	Omega float64 // Інтенсивність відмов (рік⁻¹)
+	Tfail float64 // Час відновлення (год)
+}
+
+type Input struct {
+	Pv        float64 `json:"pv"`        // Навантаження (МВт)
+	Kp        float64 `json:"kp"`        // Коефіцієнт використання
+	T         float64 `json:"t"`         // Час роботи (год)
+	Equipment string  `json:"equipment"` // Назва обладнання з довідника
+}
+
+type Result struct {
+	Qo      float64 `json:"qo"`       // Частота відмов (рік⁻¹)
+	Tavg    float64 `json:"tavg"`     // Середня тривалість відмови (год)
+	Ka      float64 `json:"ka"`       // Коефіцієнт простою
+	MEnergy float64 `json:"m_energy"` // Втрати енергії (МВт·год)
+}
+
+var equipmentData = map[string]Equipment{
+	"ПЛ-110 кВ": {"ПЛ-110 кВ", 0.007, 10},
+	"ПЛ-35 кВ":  {"ПЛ-35 кВ", 0.02, 8},
+	"Т-110 кВ":  {"Т-110 кВ", 0.015, 100},
+	"Т-35 кВ":   {"Т-35 кВ", 0.02, 80},
+}
+
+// EquipmentNames повертає відсортовані назви обладнання з довідника.
+func EquipmentNames() []string {
+	names := make([]string, 0, len(equipmentData))
+	for name := range equipmentData {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate перевіряє вхідні дані та наявність обладнання в довіднику.
+func (in Input) Validate() error {
+	errs := validate.Errors{}
+	errs.NonNegative("pv", in.Pv)
+	errs.Range("kp", in.Kp, 0, 1)
+	errs.NonNegative("t", in.T)
+	if _, ok := equipmentData[in.Equipment]; !ok {
+		errs.Add("equipment", ErrUnknownEquipment.Error())
+	}
+	return errs.Err()
+}
+
+// Calculate розраховує показники надійності для обраного обладнання.
+func Calculate(in Input) (Result, error) {
+	if err := in.Validate(); err != nil {
+		return Result{}, err
+	}
+	equip := equipmentData[in.Equipment]
+
+	Qo := equip.Omega
+	Tavg := equip.Tfail
+	Ka := (Qo * Tavg) / 8760
+	MEnergy := in.Kp * in.Pv * in.T
+
+	return Result{Qo, Tavg, Ka, MEnergy}, nil
+}
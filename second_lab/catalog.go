@@ -0,0 +1,165 @@
+package secondlab
+
+import (
+	"html/template"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"Go_tutor/emissions"
+	"Go_tutor/i18n"
+	"Go_tutor/validate"
+)
+
+// Марки палива одного типу для <optgroup>
+type fuelGroup struct {
+	Type   string
+	Grades []emissions.Grade
+}
+
+// groups розкладає довідник за типами палива, зберігаючи порядок.
+func groups(grades []emissions.Grade) []fuelGroup {
+	var out []fuelGroup
+	for _, t := range []string{emissions.TypeCoal, emissions.TypeMazut, emissions.TypeGas} {
+		g := fuelGroup{Type: t}
+		for _, grade := range grades {
+			if grade.Type == t {
+				g.Grades = append(g.Grades, grade)
+			}
+		}
+		if len(g.Grades) > 0 {
+			out = append(out, g)
+		}
+	}
+	return out
+}
+
+// Дані сторінки довідника
+type catalogPage struct {
+	i18n.Localizer
+	Form   *validate.Form
+	Grades []emissions.Grade
+	Types  []string
+}
+
+// CatalogHandler показує довідник палива й дозволяє додавати, змінювати та
+// видаляти марки.
+func CatalogHandler(w http.ResponseWriter, r *http.Request) {
+	loc := i18n.FromRequest(w, r)
+	data := catalogPage{
+		Localizer: loc,
+		Types:     []string{emissions.TypeCoal, emissions.TypeMazut, emissions.TypeGas},
+		Form:      validate.Prefilled(url.Values{"type": {emissions.TypeCoal}, "a": {"0"}, "w": {"0"}, "s": {"0"}, "c": {"0"}, "density": {"0"}}),
+	}
+
+	// Заповнюємо форму марки, обраної для редагування
+	if g, ok := emissions.Default.Get(r.URL.Query().Get("edit")); ok {
+		data.Form = validate.Prefilled(url.Values{
+			"name":    {g.Name},
+			"type":    {g.Type},
+			"a":       {formatFloat(g.A)},
+			"w":       {formatFloat(g.W)},
+			"s":       {formatFloat(g.S)},
+			"c":       {formatFloat(g.C)},
+			"q":       {formatFloat(g.Q)},
+			"density": {formatFloat(g.Density)},
+		})
+	}
+
+	if r.Method == http.MethodPost {
+		form := loc.Form(r)
+		if r.FormValue("delete") != "" {
+			if err := emissions.Default.Delete(r.FormValue("delete")); err != nil {
+				form.Fail(err)
+			}
+		} else {
+			grade := emissions.Grade{
+				Name:    form.String("name"),
+				Type:    form.String("type"),
+				A:       form.Float("a"),
+				W:       form.Float("w"),
+				S:       form.Float("s"),
+				C:       form.Float("c"),
+				Q:       form.Float("q"),
+				Density: form.Float("density"),
+			}
+			if form.Valid() {
+				if _, err := emissions.Default.Put(grade); err != nil {
+					form.Fail(err)
+				}
+			}
+		}
+		if form.Valid() {
+			// Відносна адреса, бо обробник змонтовано під префіксом
+			w.Header().Set("Location", "catalog")
+			w.WriteHeader(http.StatusSeeOther)
+			return
+		}
+		data.Form = form
+	}
+
+	data.Grades = emissions.Default.List()
+	catalogTmpl.Execute(w, data)
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+var catalogTmpl = template.Must(template.New("catalog").Parse(`
+<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+	<meta charset="UTF-8">
+	<title>{{.T "catalog.title"}}</title>
+	<style>
+		body { font-family: Arial, sans-serif; background-color: #f4f4f4; text-align: center; padding: 20px; }
+		.container { background: white; padding: 20px; border-radius: 8px; box-shadow: 0px 0px 10px rgba(0, 0, 0, 0.1); width: 70%; margin: auto; }
+		table { border-collapse: collapse; margin: 10px auto; }
+		th, td { border: 1px solid #ccc; padding: 6px 10px; }
+		input, select { padding: 6px; margin: 5px; border-radius: 5px; border: 1px solid #ccc; }
+		.error { color: #dc3545; font-size: 0.9em; }
+	</style>
+</head>
+<body>
+	{{.Switcher}}
+	<div class="container">
+		<h1>{{.T "catalog.title"}}</h1>
+		<table>
+			<tr><th>{{.T "catalog.name"}}</th><th>{{.T "catalog.type"}}</th><th>A, %</th><th>W, %</th><th>S, %</th><th>C, %</th><th>Q</th><th>{{.T "catalog.density"}}</th><th></th></tr>
+			{{range .Grades}}
+			<tr>
+				<td>{{$.T .Name}}</td>
+				<td>{{$.T (print "emissions.group." .Type)}}</td>
+				<td>{{.A}}</td><td>{{.W}}</td><td>{{.S}}</td><td>{{.C}}</td><td>{{.Q}}</td><td>{{.Density}}</td>
+				<td>
+					<a href="?edit={{.Name}}">{{$.T "catalog.edit"}}</a>
+					<form method="POST" style="display:inline"><button type="submit" name="delete" value="{{.Name}}">{{$.T "catalog.delete"}}</button></form>
+				</td>
+			</tr>
+			{{end}}
+		</table>
+		{{with .Form.Error ""}}<p class="error">{{.}}</p>{{end}}
+
+		<h2>{{.T "catalog.save"}}</h2>
+		<form method="POST" action="catalog">
+			<label>{{.T "catalog.name"}}: <input type="text" name="name" value="{{.Form.Value "name"}}"></label>{{with .Form.Error "name"}}<span class="error">{{.}}</span>{{end}}<br>
+			<label>{{.T "catalog.type"}}:
+				<select name="type">
+					{{range .Types}}<option value="{{.}}"{{if eq . ($.Form.Value "type")}} selected{{end}}>{{$.T (print "emissions.group." .)}}</option>
+					{{end}}
+				</select>
+			</label>{{with .Form.Error "type"}}<span class="error">{{.}}</span>{{end}}<br>
+			<label>{{.T "element.a"}} (A), %: <input type="text" name="a" value="{{.Form.Value "a"}}"></label>{{with .Form.Error "a"}}<span class="error">{{.}}</span>{{end}}<br>
+			<label>{{.T "element.w"}} (W), %: <input type="text" name="w" value="{{.Form.Value "w"}}"></label>{{with .Form.Error "w"}}<span class="error">{{.}}</span>{{end}}<br>
+			<label>{{.T "element.s"}} (S), %: <input type="text" name="s" value="{{.Form.Value "s"}}"></label>{{with .Form.Error "s"}}<span class="error">{{.}}</span>{{end}}<br>
+			<label>{{.T "element.c"}} (C), %: <input type="text" name="c" value="{{.Form.Value "c"}}"></label>{{with .Form.Error "c"}}<span class="error">{{.}}</span>{{end}}<br>
+			<label>{{.T "fuel.lhv"}} (Q): <input type="text" name="q" value="{{.Form.Value "q"}}"></label>{{with .Form.Error "q"}}<span class="error">{{.}}</span>{{end}}<br>
+			<label>{{.T "catalog.density"}}: <input type="text" name="density" value="{{.Form.Value "density"}}"></label>{{with .Form.Error "density"}}<span class="error">{{.}}</span>{{end}}<br>
+			<input type="submit" value="{{.T "catalog.save"}}">
+		</form>
+		<p><a href="./">{{.T "common.back"}}</a></p>
+	</div>
+</body>
+</html>
+`))
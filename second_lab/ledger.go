@@ -0,0 +1,288 @@
+package secondlab
+
+import (
+	"html/template"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"Go_tutor/emissions"
+	"Go_tutor/i18n"
+	"Go_tutor/ledger"
+	"Go_tutor/number"
+	"Go_tutor/validate"
+)
+
+// Дані сторінки журналу
+type ledgerPage struct {
+	i18n.Localizer
+	Form       *validate.Form
+	Num        number.Formatter
+	Groups     []fuelGroup
+	Burners    []string
+	Boilers    []string
+	Abatements []string
+	Units      []string
+	Plants     []string
+	Months     []int
+	Filter     ledger.Filter
+	Entries    []ledger.Entry
+	Summary    ledger.Summary
+	Checks     []checksView
+	Tax        taxView
+}
+
+// LedgerHandler показує журнал місячного споживання палива з підсумками
+// викидів з початку року та дозволяє додавати й видаляти записи.
+func LedgerHandler(w http.ResponseWriter, r *http.Request) {
+	loc := i18n.FromRequest(w, r)
+
+	// Відбір з параметрів адреси; некоректні значення ігноруються
+	q := r.URL.Query()
+	f := ledger.Filter{Plant: q.Get("plant"), Year: time.Now().Year()}
+	if year, err := strconv.Atoi(q.Get("year")); err == nil {
+		f.Year = year
+	}
+	if month, err := strconv.Atoi(q.Get("through")); err == nil && month >= 1 && month <= 12 {
+		f.Month = month
+	}
+
+	data := ledgerPage{
+		Localizer:  loc,
+		Num:        loc.Formatter(r, resultSpecs),
+		Groups:     groups(emissions.Default.List()),
+		Burners:    emissions.Burners(),
+		Boilers:    emissions.Boilers(),
+		Abatements: emissions.Abatements(),
+		Units:      emissions.Units(),
+		Months:     []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+		Filter:     f,
+		Form: validate.Prefilled(url.Values{
+			"plant": {f.Plant},
+			"year":  {strconv.Itoa(f.Year)},
+			"month": {strconv.Itoa(int(time.Now().Month()))},
+		}),
+	}
+
+	if r.Method == http.MethodPost {
+		form := loc.Form(r)
+		if id := r.FormValue("delete"); id != "" {
+			n, _ := strconv.Atoi(id)
+			if err := ledger.Default.Delete(n); err != nil {
+				form.Fail(err)
+			}
+		} else {
+			entry := ledger.Entry{
+				Plant: form.String("plant"),
+				Block: form.Value("block"),
+				Year:  form.Int("year"),
+				Month: form.Int("month"),
+				Line: emissions.Line{
+					Fuel:     form.String("fuel"),
+					Quantity: form.Float("quantity"),
+					Unit:     form.Value("unit"),
+				},
+				Equipment: emissions.Equipment{
+					Burner:    form.Value("burner"),
+					Boiler:    form.Value("boiler"),
+					Abatement: form.Value("abatement"),
+				},
+			}
+			if form.Valid() {
+				if _, err := ledger.Default.Add(entry); err != nil {
+					form.Fail(err)
+				} else {
+					f.Year = entry.Year
+				}
+			}
+		}
+		if form.Valid() {
+			// Відносна адреса, бо обробник змонтовано під префіксом
+			w.Header().Set("Location", "ledger?"+url.Values{
+				"year":  {strconv.Itoa(f.Year)},
+				"plant": {f.Plant},
+			}.Encode())
+			w.WriteHeader(http.StatusSeeOther)
+			return
+		}
+		data.Form = form
+	}
+
+	data.Plants = ledger.Default.Plants()
+	data.Summary = ledger.Default.Summary(f)
+	data.Tax = taxView{loc, data.Num, data.Summary.Tax}
+	for _, p := range ledger.Default.Permits() {
+		if f.Plant != "" && p.Plant != f.Plant {
+			continue
+		}
+		sum := ledger.Default.Summary(ledger.Filter{Plant: p.Plant, Year: f.Year, Month: f.Month})
+		data.Checks = append(data.Checks, checksView{loc, data.Num, p.Plant, sum.Checks})
+	}
+	f.Month = 0
+	data.Entries = ledger.Default.List(f)
+	ledgerTmpl.Execute(w, data)
+}
+
+var ledgerTmpl = template.Must(template.Must(template.New("ledger").Parse(checksTemplate + taxTemplate)).Parse(`
+<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+	<meta charset="UTF-8">
+	<title>{{.T "ledger.title"}}</title>
+	<style>
+		body { font-family: Arial, sans-serif; background-color: #f4f4f4; text-align: center; padding: 20px; }
+		.container { background: white; padding: 20px; border-radius: 8px; box-shadow: 0px 0px 10px rgba(0, 0, 0, 0.1); width: 80%; margin: auto; }
+		table { border-collapse: collapse; margin: 10px auto; }
+		th, td { border: 1px solid #ccc; padding: 6px 10px; }
+		input, select { padding: 6px; margin: 5px; border-radius: 5px; border: 1px solid #ccc; }
+		.error { color: #dc3545; font-size: 0.9em; }
+		tr.warning td { background-color: #fff3cd; }
+		tr.exceeded td { background-color: #f8d7da; }
+	</style>
+</head>
+<body>
+	{{.Switcher}}
+	<div class="container">
+		<h1>{{.T "ledger.title"}}</h1>
+		<form method="GET" action="ledger">
+			<label>{{.T "ledger.year"}}: <input type="text" name="year" size="4" value="{{.Filter.Year}}"></label>
+			<label>{{.T "ledger.plant"}}:
+				<select name="plant">
+					<option value="">{{.T "ledger.all"}}</option>
+					{{range .Plants}}<option value="{{.}}"{{if eq . $.Filter.Plant}} selected{{end}}>{{.}}</option>
+					{{end}}
+				</select>
+			</label>
+			<label>{{.T "ledger.through"}}:
+				<select name="through">
+					<option value="">—</option>
+					{{range .Months}}<option value="{{.}}"{{if eq . $.Filter.Month}} selected{{end}}>{{printf "%02d" .}}</option>
+					{{end}}
+				</select>
+			</label>
+			<input type="submit" value="{{.T "ledger.show"}}">
+		</form>
+
+		{{with .Summary}}
+		<h2>{{$.T "ledger.ytd" .Year (printf "%02d" .Through)}}</h2>
+		{{with .Total}}
+		<table>
+			<tr><th>{{$.T "emissions.pollutant"}}</th><th>{{$.T "emissions.gross"}}</th><th>{{$.T "emissions.factor"}}</th></tr>
+			<tr><td>{{$.T "pollutant.particulates"}}</td><td>{{$.Num.Format "total" .Particulates.Total}}</td><td>{{$.Num.Format "factor" .Particulates.Factor}}</td></tr>
+			<tr><td>{{$.T "pollutant.so2"}}</td><td>{{$.Num.Format "total" .SO2.Total}}</td><td>{{$.Num.Format "factor" .SO2.Factor}}</td></tr>
+			<tr><td>{{$.T "pollutant.nox"}}</td><td>{{$.Num.Format "total" .NOx.Total}}</td><td>{{$.Num.Format "factor" .NOx.Factor}}</td></tr>
+			<tr><td>{{$.T "pollutant.co2"}}</td><td>{{$.Num.Format "total" .CO2.Total}}</td><td>{{$.Num.Format "factor" .CO2.Factor}}</td></tr>
+			<tr><th>{{$.T "emissions.energy"}}</th><th colspan="2">{{$.Num.Format "energy" .Energy}}</th></tr>
+		</table>
+		{{end}}
+		{{if .ByFuel}}
+		<h3>{{$.T "ledger.by_fuel"}}</h3>
+		<table>
+			<tr>
+				<th>{{$.T "emissions.fuel"}}</th><th>{{$.T "emissions.energy"}}</th>
+				<th>{{$.T "pollutant.particulates"}}</th><th>{{$.T "pollutant.so2"}}</th><th>{{$.T "pollutant.nox"}}</th><th>{{$.T "pollutant.co2"}}</th>
+			</tr>
+			{{range .ByFuel}}
+			<tr>
+				<td>{{$.T .Fuel}}</td><td>{{$.Num.Format "energy" .Energy}}</td>
+				<td>{{$.Num.Format "total" .Particulates.Total}}</td><td>{{$.Num.Format "total" .SO2.Total}}</td>
+				<td>{{$.Num.Format "total" .NOx.Total}}</td><td>{{$.Num.Format "total" .CO2.Total}}</td>
+			</tr>
+			{{end}}
+		</table>
+		<h3>{{$.T "ledger.by_month"}}</h3>
+		<table>
+			<tr>
+				<th>{{$.T "ledger.month"}}</th><th>{{$.T "emissions.energy"}}</th>
+				<th>{{$.T "pollutant.particulates"}}</th><th>{{$.T "pollutant.so2"}}</th><th>{{$.T "pollutant.nox"}}</th><th>{{$.T "pollutant.co2"}}</th>
+			</tr>
+			{{range .ByMonth}}
+			<tr>
+				<td>{{printf "%02d" .Month}}</td><td>{{$.Num.Format "energy" .Energy}}</td>
+				<td>{{$.Num.Format "total" .Particulates.Total}}</td><td>{{$.Num.Format "total" .SO2.Total}}</td>
+				<td>{{$.Num.Format "total" .NOx.Total}}</td><td>{{$.Num.Format "total" .CO2.Total}}</td>
+			</tr>
+			{{end}}
+		</table>
+		{{end}}
+		{{end}}
+		{{template "tax" .Tax}}
+		{{range .Checks}}{{template "checks" .}}{{end}}
+
+		<h2>{{.T "ledger.entries"}}</h2>
+		{{with .Form.Error ""}}<p class="error">{{.}}</p>{{end}}
+		{{if .Entries}}
+		<table>
+			<tr>
+				<th>{{.T "ledger.period"}}</th><th>{{.T "ledger.plant"}}</th><th>{{.T "ledger.block"}}</th>
+				<th>{{.T "emissions.fuel"}}</th><th>{{.T "emissions.quantity"}}</th><th>{{.T "emissions.energy"}}</th>
+				<th>{{.T "pollutant.particulates"}}</th><th>{{.T "pollutant.so2"}}</th><th>{{.T "pollutant.nox"}}</th><th>{{.T "pollutant.co2"}}</th><th></th>
+			</tr>
+			{{range .Entries}}
+			<tr>
+				<td>{{printf "%02d" .Month}}.{{.Year}}</td><td>{{.Plant}}</td><td>{{.Block}}</td>
+				<td>{{$.T .Fuel}}</td><td>{{$.Num.Format "quantity" .Quantity}} {{$.T (print "unit." .Result.Unit)}}</td>
+				{{with .Result}}
+				<td>{{$.Num.Format "energy" .Energy}}</td>
+				<td>{{$.Num.Format "total" .TotalEmission}}</td><td>{{$.Num.Format "total" .SO2.Total}}</td>
+				<td>{{$.Num.Format "total" .NOx.Total}}</td><td>{{$.Num.Format "total" .CO2.Total}}</td>
+				{{end}}
+				<td><form method="POST" style="display:inline"><button type="submit" name="delete" value="{{.ID}}">{{$.T "catalog.delete"}}</button></form></td>
+			</tr>
+			{{end}}
+		</table>
+		{{else}}
+		<p>{{.T "ledger.empty"}}</p>
+		{{end}}
+
+		<h2>{{.T "ledger.add"}}</h2>
+		<form method="POST">
+			<label>{{.T "ledger.plant"}}: <input type="text" name="plant" value="{{.Form.Value "plant"}}"></label>{{with .Form.Error "plant"}}<span class="error">{{.}}</span>{{end}}
+			<label>{{.T "ledger.block"}}: <input type="text" name="block" value="{{.Form.Value "block"}}"></label><br>
+			<label>{{.T "ledger.year"}}: <input type="text" name="year" size="4" value="{{.Form.Value "year"}}"></label>{{with .Form.Error "year"}}<span class="error">{{.}}</span>{{end}}
+			<label>{{.T "ledger.month"}}: <input type="text" name="month" size="2" value="{{.Form.Value "month"}}"></label>{{with .Form.Error "month"}}<span class="error">{{.}}</span>{{end}}<br>
+			<select name="fuel">
+				<option value="">{{.T "emissions.fuel"}}</option>
+				{{range .Groups}}
+				<optgroup label="{{$.T (print "emissions.group." .Type)}}">
+					{{range .Grades}}<option value="{{.Name}}"{{if eq ($.Form.Value "fuel") .Name}} selected{{end}}>{{$.T .Name}}</option>
+					{{end}}
+				</optgroup>
+				{{end}}
+			</select>
+			{{with .Form.Error "fuel"}}<span class="error">{{.}}</span>{{end}}
+			<input type="text" name="quantity" placeholder="{{.T "emissions.quantity"}}" value="{{.Form.Value "quantity"}}">
+			{{with .Form.Error "quantity"}}<span class="error">{{.}}</span>{{end}}
+			<select name="unit">
+				<option value="">{{.T "emissions.by_fuel"}}</option>
+				{{range .Units}}<option value="{{.}}"{{if eq . ($.Form.Value "unit")}} selected{{end}}>{{$.T (print "unit." .)}}</option>
+				{{end}}
+			</select>
+			{{with .Form.Error "unit"}}<span class="error">{{.}}</span>{{end}}<br>
+			<label>{{.T "emissions.burner"}}:
+				<select name="burner">
+					{{range .Burners}}<option value="{{.}}"{{if eq . ($.Form.Value "burner")}} selected{{end}}>{{$.T (print "burner." .)}}</option>
+					{{end}}
+				</select>
+			</label>
+			<label>{{.T "emissions.boiler"}}:
+				<select name="boiler">
+					<option value="">{{.T "emissions.by_fuel"}}</option>
+					{{range .Boilers}}<option value="{{.}}"{{if eq . ($.Form.Value "boiler")}} selected{{end}}>{{$.T (print "boiler." .)}}</option>
+					{{end}}
+				</select>
+			</label>
+			<label>{{.T "emissions.abatement"}}:
+				<select name="abatement">
+					{{range .Abatements}}<option value="{{.}}"{{if eq . ($.Form.Value "abatement")}} selected{{end}}>{{$.T (print "abatement." .)}}</option>
+					{{end}}
+				</select>
+			</label><br>
+			<input type="submit" value="{{.T "ledger.add"}}">
+		</form>
+		<p><a href="./">{{.T "common.back"}}</a> · <a href="permits">{{.T "permit.title"}}</a></p>
+	</div>
+</body>
+</html>
+`))
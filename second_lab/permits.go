@@ -0,0 +1,216 @@
+package secondlab
+
+import (
+	"html/template"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"Go_tutor/emissions"
+	"Go_tutor/i18n"
+	"Go_tutor/ledger"
+	"Go_tutor/number"
+	"Go_tutor/validate"
+)
+
+// Таблиця перевірки дозволу, що виконується для checksView.
+const checksTemplate = `
+{{define "checks"}}
+{{if .Checks}}
+<h3>{{.T "permit.check"}}: {{.Plant}}</h3>
+<table>
+	<tr>
+		<th>{{.T "emissions.pollutant"}}</th><th>{{.T "permit.kind"}}</th><th>{{.T "permit.value"}}</th>
+		<th>{{.T "permit.limit"}}</th><th>{{.T "permit.share"}}</th><th>{{.T "permit.status"}}</th>
+	</tr>
+	{{range .Checks}}
+	<tr class="{{.Status}}">
+		<td>{{$.T (print "pollutant." .Pollutant)}}</td><td>{{$.T (print "permit.kind." .Kind)}}</td>
+		{{if eq .Kind "annual"}}<td>{{$.Num.Format "total" .Value}}</td><td>{{$.Num.Format "total" .Limit}}</td>
+		{{else}}<td>{{$.Num.Format "factor" .Value}}</td><td>{{$.Num.Format "factor" .Limit}}</td>{{end}}
+		<td>{{$.Num.Format "share" .Percent}}</td><td>{{$.T (print "permit.status." .Status)}}</td>
+	</tr>
+	{{end}}
+</table>
+{{end}}
+{{end}}
+`
+
+// Дані сторінки дозволів
+type permitsPage struct {
+	i18n.Localizer
+	Form       *validate.Form
+	Permits    []ledger.Permit
+	Pollutants []string
+}
+
+// PermitsHandler показує дозволи на викиди електростанцій і дозволяє
+// задавати та видаляти ліміти.
+func PermitsHandler(w http.ResponseWriter, r *http.Request) {
+	loc := i18n.FromRequest(w, r)
+	data := permitsPage{
+		Localizer:  loc,
+		Pollutants: emissions.Pollutants(),
+		Form:       validate.Prefilled(url.Values{}),
+	}
+
+	// Заповнюємо форму дозволу, обраного для редагування
+	if p, ok := ledger.Default.Permit(r.URL.Query().Get("edit")); ok {
+		values := url.Values{"plant": {p.Plant}}
+		if p.Warning != 0 {
+			values.Set("warning", formatFloat(p.Warning))
+		}
+		for _, l := range p.Limits {
+			if l.Annual != 0 {
+				values.Set("annual."+l.Pollutant, formatFloat(l.Annual))
+			}
+			if l.Factor != 0 {
+				values.Set("factor."+l.Pollutant, formatFloat(l.Factor))
+			}
+		}
+		data.Form = validate.Prefilled(values)
+	}
+
+	if r.Method == http.MethodPost {
+		form := loc.Form(r)
+		if r.FormValue("delete") != "" {
+			if err := ledger.Default.DeletePermit(r.FormValue("delete")); err != nil {
+				form.Fail(err)
+			}
+		} else {
+			permit := ledger.Permit{Plant: form.String("plant")}
+			if warning := form.OptionalFloat("warning"); warning != nil {
+				permit.Warning = *warning
+			}
+			// Порожні поля означають відсутність ліміту; pollutantOf
+			// зберігає речовину кожного ліміту для повідомлень про помилки.
+			var pollutantOf []string
+			for _, p := range data.Pollutants {
+				annual, factor := form.OptionalFloat("annual."+p), form.OptionalFloat("factor."+p)
+				if annual == nil && factor == nil {
+					continue
+				}
+				l := ledger.Limit{Pollutant: p}
+				if annual != nil {
+					l.Annual = *annual
+				}
+				if factor != nil {
+					l.Factor = *factor
+				}
+				permit.Limits = append(permit.Limits, l)
+				pollutantOf = append(pollutantOf, p)
+			}
+			if form.Valid() {
+				if _, err := ledger.Default.PutPermit(permit); err != nil {
+					form.Fail(limitFields(err, pollutantOf))
+				}
+			}
+		}
+		if form.Valid() {
+			// Відносна адреса, бо обробник змонтовано під префіксом
+			w.Header().Set("Location", "permits")
+			w.WriteHeader(http.StatusSeeOther)
+			return
+		}
+		data.Form = form
+	}
+
+	data.Permits = ledger.Default.Permits()
+	permitsTmpl.Execute(w, data)
+}
+
+// limitFields переносить помилки лімітів "limits.N.annual" на поля форми
+// "annual.so2".
+func limitFields(err error, pollutantOf []string) error {
+	errs, ok := validate.Fields(err)
+	if !ok {
+		return err
+	}
+	out := validate.Errors{}
+	for field, m := range errs {
+		parts := strings.SplitN(field, ".", 3)
+		if len(parts) == 3 && parts[0] == "limits" {
+			if i, err := strconv.Atoi(parts[1]); err == nil && i < len(pollutantOf) {
+				field = parts[2] + "." + pollutantOf[i]
+			}
+		}
+		out[field] = m
+	}
+	return out
+}
+
+// checksView — перевірка дозволу однієї електростанції для шаблону
+// "checks".
+type checksView struct {
+	i18n.Localizer
+	Num    number.Formatter
+	Plant  string
+	Checks []ledger.Check
+}
+
+var permitsTmpl = template.Must(template.New("permits").Parse(`
+<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+	<meta charset="UTF-8">
+	<title>{{.T "permit.title"}}</title>
+	<style>
+		body { font-family: Arial, sans-serif; background-color: #f4f4f4; text-align: center; padding: 20px; }
+		.container { background: white; padding: 20px; border-radius: 8px; box-shadow: 0px 0px 10px rgba(0, 0, 0, 0.1); width: 70%; margin: auto; }
+		table { border-collapse: collapse; margin: 10px auto; }
+		th, td { border: 1px solid #ccc; padding: 6px 10px; }
+		input, select { padding: 6px; margin: 5px; border-radius: 5px; border: 1px solid #ccc; }
+		.error { color: #dc3545; font-size: 0.9em; }
+	</style>
+</head>
+<body>
+	{{.Switcher}}
+	<div class="container">
+		<h1>{{.T "permit.title"}}</h1>
+		{{if .Permits}}
+		<table>
+			<tr>
+				<th rowspan="2">{{.T "ledger.plant"}}</th>
+				{{range .Pollutants}}<th colspan="2">{{$.T (print "pollutant." .)}}</th>{{end}}
+				<th rowspan="2">{{.T "permit.warning"}}</th><th rowspan="2"></th>
+			</tr>
+			<tr>{{range .Pollutants}}<th>{{$.T "unit.t_per_year"}}</th><th>{{$.T "unit.g_per_gj"}}</th>{{end}}</tr>
+			{{range $p := .Permits}}
+			<tr>
+				<td>{{.Plant}}</td>
+				{{range $.Pollutants}}{{$l := $p.Limit .}}<td>{{with $l.Annual}}{{.}}{{end}}</td><td>{{with $l.Factor}}{{.}}{{end}}</td>{{end}}
+				<td>{{with .Warning}}{{.}}{{else}}—{{end}}</td>
+				<td>
+					<a href="?edit={{.Plant}}">{{$.T "catalog.edit"}}</a>
+					<form method="POST" style="display:inline"><button type="submit" name="delete" value="{{.Plant}}">{{$.T "catalog.delete"}}</button></form>
+				</td>
+			</tr>
+			{{end}}
+		</table>
+		{{else}}
+		<p>{{.T "permit.empty"}}</p>
+		{{end}}
+		{{with .Form.Error ""}}<p class="error">{{.}}</p>{{end}}
+
+		<h2>{{.T "permit.save"}}</h2>
+		<form method="POST" action="permits">
+			<label>{{.T "ledger.plant"}}: <input type="text" name="plant" value="{{.Form.Value "plant"}}"></label>{{with .Form.Error "plant"}}<span class="error">{{.}}</span>{{end}}<br>
+			<label>{{.T "permit.warning"}}: <input type="text" name="warning" placeholder="0,9" value="{{.Form.Value "warning"}}"></label>{{with .Form.Error "warning"}}<span class="error">{{.}}</span>{{end}}
+			<table>
+				<tr><th>{{.T "emissions.pollutant"}}</th><th>{{.T "permit.kind.annual"}}</th><th>{{.T "permit.kind.factor"}}</th></tr>
+				{{range .Pollutants}}{{$annual := print "annual." .}}{{$factor := print "factor." .}}
+				<tr>
+					<td>{{$.T (print "pollutant." .)}}</td>
+					<td><input type="text" name="{{$annual}}" value="{{$.Form.Value $annual}}">{{with $.Form.Error $annual}}<span class="error">{{.}}</span>{{end}}</td>
+					<td><input type="text" name="{{$factor}}" value="{{$.Form.Value $factor}}">{{with $.Form.Error $factor}}<span class="error">{{.}}</span>{{end}}</td>
+				</tr>
+				{{end}}
+			</table>
+			<input type="submit" value="{{.T "permit.save"}}">
+		</form>
+		<p><a href="./">{{.T "common.back"}}</a> · <a href="ledger">{{.T "ledger.title"}}</a></p>
+	</div>
+</body>
+</html>
+`))
@@ -1,180 +1,312 @@
-package main
+package secondlab
 
 import (
-	"fmt"
 	"html/template"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
+
+	"Go_tutor/emissions"
+	"Go_tutor/i18n"
+	"Go_tutor/ledger"
+	"Go_tutor/number"
+	"Go_tutor/tax"
+	"Go_tutor/validate"
 )
 
-// Структура для вхідних даних
-type InputData struct {
-	FuelType string
-	FuelMass float64
-}
+// Кількість рядків палива у формі за замовчуванням
+const defaultRows = 3
 
-// Структура для результатів
-type Result struct {
-	EmissionFactor float64
-	TotalEmission  float64
+// Дані сторінки
+type pageData struct {
+	i18n.Localizer
+	Form       *validate.Form
+	Num        number.Formatter
+	Groups     []fuelGroup
+	Burners    []string
+	Boilers    []string
+	Abatements []string
+	Units      []string
+	Plants     []string
+	Year       int // Поточний рік, за замовчуванням для ставок податку
+	Rows       []int
+	Result     *emissions.BlendResult
+	Checks     []checksView
+	Tax        taxView
 }
 
-// Дані про вугілля та мазут
-var fuelData = map[string]struct {
-	A    float64 // Зольність (%)
-	W    float64 // Вологість (%)
-	Q    float64 // Нижча теплота згоряння (МДж/кг)
-	Type string  // Тип палива (вугілля чи мазут)
-}{
-	// Вугілля
-	"Антрацитовий штиб АШ":        {A: 5.0, W: 3.0, Q: 33.24, Type: "coal"},
-	"Пісне вугілля ТР":            {A: 12.0, W: 6.0, Q: 34.29, Type: "coal"},
-	"Донецьке газове ГР":          {A: 25.20, W: 10.0, Q: 31.98, Type: "coal"},
-	"Донецьке довгополуменеве ДР": {A: 35.0, W: 15.0, Q: 30.56, Type: "coal"},
-	"Львівсько-волинське (ЛВ) ГР": {A: 18.0, W: 10.0, Q: 31.69, Type: "coal"},
-	"Олександрійське буре БІР":    {A: 45.0, W: 25.0, Q: 26.96, Type: "coal"},
-	// Мазут
-	"Високосірчастий 40":  {A: 0.15, W: 2.00, Q: 40.40, Type: "mazut"},
-	"Високосірчастий 100": {A: 0.15, W: 2.00, Q: 40.03, Type: "mazut"},
-	"Високосірчастий 200": {A: 0.30, W: 1.00, Q: 39.77, Type: "mazut"},
-	"Малосірчастий 40":    {A: 0.15, W: 2.00, Q: 41.24, Type: "mazut"},
-	"Малосірчастий 100":   {A: 0.15, W: 2.00, Q: 40.82, Type: "mazut"},
-}
+// Формат результатів
+var resultSpecs = number.Specs{
+	"total":    {Precision: 3, Unit: "unit.t"},
+	"factor":   {Precision: 2, Unit: "unit.g_per_gj"},
+	"energy":   {Precision: 1, Unit: "unit.gj"},
+	"quantity": {Precision: 3},
+	"share":    {Precision: 1, Unit: "unit.percent"},
+	"rate":     {Precision: 2, Unit: "unit.uah_per_t"},
+	"amount":   {Precision: 2, Unit: "unit.uah"},
 
-// Дані про газ
-var gasData = map[string]struct {
-	Q  float64 // Нижча теплота згоряння (МДж/нм³)
-	Ro float64 // Щільність (кг/нм³)
-}{
-	"Уренгой—Ужгород":    {Q: 33.08, Ro: 0.723},
-	"Середня Азія—Центр": {Q: 34.21, Ro: 0.764},
+	"fly_ash":            {Precision: 2},
+	"combustible_in_ash": {Precision: 1, Unit: "unit.percent"},
+	"efficiency":         {Precision: 3},
 }
 
-// Функція для розрахунку викидів
-func calculateEmission(fuelType string, fuelMass float64) Result {
-	fuel, exists := fuelData[fuelType]
-	if !exists {
-		return Result{}
+func newPage(loc i18n.Localizer, rows int) pageData {
+	data := pageData{
+		Localizer:  loc,
+		Groups:     groups(emissions.Default.List()),
+		Burners:    emissions.Burners(),
+		Boilers:    emissions.Boilers(),
+		Abatements: emissions.Abatements(),
+		Units:      emissions.Units(),
+		Year:       time.Now().Year(),
 	}
-
-	var k, E float64
-	if fuel.Type == "coal" {
-		qr := fuel.Q * (1 - ((fuel.W + fuel.A) / 100))
-		first := (1000000 / qr) * 0.8
-		second := (fuel.A / (100 - 1.5)) * (1 - 0.985)
-		k = first * second
-		E = 0.000001 * k * qr * fuelMass
-	} else if fuel.Type == "mazut" {
-		first := (1000000 / fuel.Q) * 1
-		second := (fuel.A / 100) * (1 - 0.985)
-		k = first * second
-		E = 0.000001 * k * fuel.Q * fuelMass
+	for _, p := range ledger.Default.Permits() {
+		data.Plants = append(data.Plants, p.Plant)
 	}
-
-	return Result{
-		EmissionFactor: k,
-		TotalEmission:  E,
+	for i := 0; i < rows; i++ {
+		data.Rows = append(data.Rows, i)
 	}
+	return data
 }
 
-// Функція для розрахунку викидів газу
-func calculateGasEmission(gasType string, gasVolume float64) Result {
-	gas, exists := gasData[gasType]
-	if !exists {
-		return Result{}
+// Обробник форми
+func FormHandler(w http.ResponseWriter, r *http.Request) {
+	loc := i18n.FromRequest(w, r)
+	if r.Method != http.MethodPost {
+		tmpl.Execute(w, newPage(loc, defaultRows))
+		return
 	}
 
-	// Формула для розрахунку викидів
-	first := (1000000 / gas.Q) * 0.8
-	E := 0.000001 * first * gas.Q * gasVolume
-
-	return Result{
-		EmissionFactor: first,
-		TotalEmission:  E,
+	form := loc.Form(r)
+	rows, err := strconv.Atoi(form.Value("rows"))
+	if err != nil || rows < 1 || rows > 20 {
+		rows = defaultRows
 	}
-}
+	data := newPage(loc, rows)
+	data.Form, data.Num = form, loc.Formatter(r, resultSpecs)
 
-// Обробник форми
-func formHandler(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		tmpl.Execute(w, nil)
+	// Кнопка «Додати паливо» лише показує ще один рядок
+	if form.Value("add_row") != "" {
+		if rows < 20 {
+			data.Rows = append(data.Rows, rows)
+		}
+		tmpl.Execute(w, data)
 		return
 	}
 
-	fuelType := r.FormValue("fuelType")
-	fuelMass, _ := strconv.ParseFloat(r.FormValue("fuelMass"), 64)
+	// Порожні рядки пропускаються; rowOf зберігає номер рядка форми для
+	// кожного палива суміші.
+	input := emissions.BlendInput{
+		Equipment: emissions.Equipment{
+			Burner:           form.Value("burner"),
+			Boiler:           form.Value("boiler"),
+			Abatement:        form.Value("abatement"),
+			FlyAsh:           form.OptionalFloat("fly_ash"),
+			CombustibleInAsh: form.OptionalFloat("combustible_in_ash"),
+			Efficiency:       form.OptionalFloat("efficiency"),
+		},
+	}
+	var rowOf []int
+	for i := 0; i < rows; i++ {
+		fuel, quantity, unit := emissions.LineField(i, "fuel"), emissions.LineField(i, "quantity"), emissions.LineField(i, "unit")
+		if form.Value(fuel) == "" && strings.TrimSpace(form.Value(quantity)) == "" {
+			continue
+		}
+		input.Lines = append(input.Lines, emissions.Line{
+			Fuel:     form.String(fuel),
+			Quantity: form.Float(quantity),
+			Unit:     form.Value(unit),
+		})
+		rowOf = append(rowOf, i)
+	}
+
+	// Рік для ставок податку, за замовчуванням поточний
+	year := data.Year
+	if form.Value("year") != "" {
+		year = form.Int("year")
+	}
 
-	var result Result
-	if _, exists := fuelData[fuelType]; exists {
-		result = calculateEmission(fuelType, fuelMass)
-	} else if _, exists := gasData[fuelType]; exists {
-		result = calculateGasEmission(fuelType, fuelMass)
-	} else {
-		// Якщо тип палива невідомий, повертаємо порожній результат
-		result = Result{}
+	if form.Valid() {
+		if result, err := emissions.CalculateBlend(input); err != nil {
+			form.Fail(renumber(err, rowOf))
+		} else {
+			data.Result = &result
+			// Перевірка дозволу разом із викидами, вже внесеними до журналу
+			// за рік year — лише для станції, яку обрав користувач. Дозвіл
+			// міг бути видалений після завантаження форми.
+			if plant := form.Value("plant"); plant != "" {
+				if checks, err := ledger.Default.Check(plant, year, result.Total); err != nil {
+					form.FailField("plant", err)
+				} else {
+					data.Checks = []checksView{{loc, data.Num, plant, checks}}
+				}
+			}
+			if t, err := tax.Default.Calculate(year, result.Total); err != nil {
+				form.FailField("year", err)
+			} else {
+				data.Tax = taxView{loc, data.Num, &t}
+			}
+		}
 	}
+	tmpl.Execute(w, data)
+}
 
-	tmpl.Execute(w, struct {
-		Input  InputData
-		Result Result
-	}{Input: InputData{FuelType: fuelType, FuelMass: fuelMass}, Result: result})
+// renumber переносить помилки рядків суміші на відповідні рядки форми.
+func renumber(err error, rowOf []int) error {
+	errs, ok := validate.Fields(err)
+	if !ok {
+		return err
+	}
+	out := validate.Errors{}
+	for field, m := range errs {
+		parts := strings.SplitN(field, ".", 3)
+		if len(parts) == 3 && parts[0] == "lines" {
+			if i, err := strconv.Atoi(parts[1]); err == nil && i < len(rowOf) {
+				field = emissions.LineField(rowOf[i], parts[2])
+			}
+		}
+		out[field] = m
+	}
+	return out
 }
 
 // Шаблон HTML
-var tmpl = template.Must(template.New("form").Parse(`
+var tmpl = template.Must(template.Must(template.New("form").Parse(checksTemplate + taxTemplate)).Parse(`
 <!DOCTYPE html>
-<html>
+<html lang="{{.Lang}}">
 <head>
-	<title>Калькулятор викидів</title>
+	<meta charset="UTF-8">
+	<title>{{.T "emissions.title"}}</title>
 	<style>
 		body { font-family: Arial, sans-serif; background-color: #f4f4f4; text-align: center; padding: 20px; }
-		.container { background: white; padding: 20px; border-radius: 8px; box-shadow: 0px 0px 10px rgba(0, 0, 0, 0.1); width: 50%; margin: auto; }
-		input, select { padding: 10px; margin: 10px; width: 80%; border-radius: 5px; border: 1px solid #ccc; }
+		.container { background: white; padding: 20px; border-radius: 8px; box-shadow: 0px 0px 10px rgba(0, 0, 0, 0.1); width: 70%; margin: auto; }
+		input, select { padding: 10px; margin: 10px; border-radius: 5px; border: 1px solid #ccc; }
 		input[type="submit"] { background-color: #28a745; color: white; border: none; cursor: pointer; }
 		input[type="submit"]:hover { background-color: #218838; }
+		.error { color: #dc3545; font-size: 0.9em; }
+		table { border-collapse: collapse; margin: 10px auto; }
+		th, td { border: 1px solid #ccc; padding: 6px 10px; }
+		tr.warning td { background-color: #fff3cd; }
+		tr.exceeded td { background-color: #f8d7da; }
 	</style>
 </head>
 <body>
+	{{.Switcher}}
 	<div class="container">
-		<h1>Калькулятор викидів</h1>
+		<h1>{{.T "emissions.title"}}</h1>
 		<form method="POST">
-			<label>Тип палива: </label>
-			<select name="fuelType">
-				<optgroup label="Вугілля">
-					<option value="Антрацитовий штиб АШ">Антрацитовий штиб АШ</option>
-					<option value="Пісне вугілля ТР">Пісне вугілля ТР</option>
-					<option value="Донецьке газове ГР">Донецьке газове ГР</option>
-					<option value="Донецьке довгополуменеве ДР">Донецьке довгополуменеве ДР</option>
-					<option value="Львівсько-волинське (ЛВ) ГР">Львівсько-волинське (ЛВ) ГР</option>
-					<option value="Олександрійське буре БІР">Олександрійське буре БІР</option>
-				</optgroup>
-				<optgroup label="Мазут">
-					<option value="Високосірчастий 40">Високосірчастий 40</option>
-					<option value="Високосірчастий 100">Високосірчастий 100</option>
-					<option value="Високосірчастий 200">Високосірчастий 200</option>
-					<option value="Малосірчастий 40">Малосірчастий 40</option>
-					<option value="Малосірчастий 100">Малосірчастий 100</option>
-				</optgroup>
-				<optgroup label="Газ">
-					<option value="Уренгой—Ужгород">Уренгой—Ужгород</option>
-					<option value="Середня Азія—Центр">Середня Азія—Центр</option>
-				</optgroup>
+			<input type="hidden" name="rows" value="{{len .Rows}}">
+			<table>
+				<tr><th>{{.T "emissions.fuel"}}</th><th>{{.T "emissions.quantity"}}</th><th>{{.T "emissions.unit"}}</th></tr>
+				{{range $i := .Rows}}
+				{{$fuel := print "lines." $i ".fuel"}}{{$quantity := print "lines." $i ".quantity"}}{{$unit := print "lines." $i ".unit"}}
+				<tr>
+					<td>
+						<select name="{{$fuel}}">
+							<option value="">—</option>
+							{{range $.Groups}}
+							<optgroup label="{{$.T (print "emissions.group." .Type)}}">
+								{{range .Grades}}<option value="{{.Name}}"{{if eq ($.Form.Value $fuel) .Name}} selected{{end}}>{{$.T .Name}}</option>
+								{{end}}
+							</optgroup>
+							{{end}}
+						</select>
+						{{with $.Form.Error $fuel}}<span class="error">{{.}}</span>{{end}}
+					</td>
+					<td>
+						<input type="text" name="{{$quantity}}" value="{{$.Form.Value $quantity}}">
+						{{with $.Form.Error $quantity}}<span class="error">{{.}}</span>{{end}}
+					</td>
+					<td>
+						<select name="{{$unit}}">
+							<option value="">{{$.T "emissions.by_fuel"}}</option>
+							{{range $.Units}}<option value="{{.}}"{{if eq . ($.Form.Value $unit)}} selected{{end}}>{{$.T (print "unit." .)}}</option>
+							{{end}}
+						</select>
+						{{with $.Form.Error $unit}}<span class="error">{{.}}</span>{{end}}
+					</td>
+				</tr>
+				{{end}}
+			</table>
+			{{with .Form.Error "lines"}}<p class="error">{{.}}</p>{{end}}
+			<button type="submit" name="add_row" value="1">{{.T "emissions.add_fuel"}}</button><br>
+			<label>{{.T "emissions.burner"}}: </label>
+			<select name="burner">
+				{{range .Burners}}<option value="{{.}}"{{if eq . ($.Form.Value "burner")}} selected{{end}}>{{$.T (print "burner." .)}}</option>
+				{{end}}
 			</select>
-			<input type="text" name="fuelMass" placeholder="Кількість (т)"><br>
-			<input type="submit" value="Розрахувати">
+			{{with .Form.Error "burner"}}<span class="error">{{.}}</span>{{end}}<br>
+			<label>{{.T "emissions.boiler"}}: </label>
+			<select name="boiler">
+				<option value="">{{.T "emissions.by_fuel"}}</option>
+				{{range .Boilers}}<option value="{{.}}"{{if eq . ($.Form.Value "boiler")}} selected{{end}}>{{$.T (print "boiler." .)}}</option>
+				{{end}}
+			</select>
+			{{with .Form.Error "boiler"}}<span class="error">{{.}}</span>{{end}}<br>
+			<label>{{.T "emissions.abatement"}}: </label>
+			<select name="abatement">
+				{{range .Abatements}}<option value="{{.}}"{{if eq . ($.Form.Value "abatement")}} selected{{end}}>{{$.T (print "abatement." .)}}</option>
+				{{end}}
+			</select>
+			{{with .Form.Error "abatement"}}<span class="error">{{.}}</span>{{end}}<br>
+			<input type="text" name="fly_ash" placeholder="{{.T "emissions.fly_ash"}} ({{.T "emissions.preset"}})" value="{{.Form.Value "fly_ash"}}">
+			{{with .Form.Error "fly_ash"}}<span class="error">{{.}}</span>{{end}}<br>
+			<input type="text" name="combustible_in_ash" placeholder="{{.T "emissions.combustible_in_ash"}} ({{.T "emissions.preset"}})" value="{{.Form.Value "combustible_in_ash"}}">
+			{{with .Form.Error "combustible_in_ash"}}<span class="error">{{.}}</span>{{end}}<br>
+			<input type="text" name="efficiency" placeholder="{{.T "emissions.efficiency"}} ({{.T "emissions.preset"}})" value="{{.Form.Value "efficiency"}}">
+			{{with .Form.Error "efficiency"}}<span class="error">{{.}}</span>{{end}}<br>
+			<label>{{.T "tax.year"}}: </label>
+			<input type="text" name="year" size="4" placeholder="{{.Year}}" value="{{.Form.Value "year"}}">
+			{{with .Form.Error "year"}}<span class="error">{{.}}</span>{{end}}<br>
+			{{if .Plants}}
+			<label>{{.T "permit.check"}}: </label>
+			<select name="plant">
+				<option value="">—</option>
+				{{range .Plants}}<option value="{{.}}"{{if eq . ($.Form.Value "plant")}} selected{{end}}>{{.}}</option>
+				{{end}}
+			</select>
+			{{with .Form.Error "plant"}}<span class="error">{{.}}</span>{{end}}<br>
+			{{end}}
+			{{with .Form.Error ""}}<p class="error">{{.}}</p>{{end}}
+			<input type="submit" value="{{.T "common.calculate"}}">
 		</form>
-		{{if .Result.TotalEmission}}
-		<h2>Результати:</h2>
-		<p>Викиди від {{.Input.FuelType}}: {{.Result.TotalEmission}} т</p>
-		<p>Коефіцієнт викиду: {{.Result.EmissionFactor}}</p>
+		<p><a href="catalog">{{.T "catalog.title"}}</a> · <a href="ledger">{{.T "ledger.title"}}</a> · <a href="permits">{{.T "permit.title"}}</a> · <a href="tax">{{.T "tax.title"}}</a></p>
+		{{with .Result}}
+		<h2>{{$.T "common.results"}}:</h2>
+		<table>
+			<tr>
+				<th>{{$.T "emissions.fuel"}}</th><th>{{$.T "emissions.quantity"}}</th><th>{{$.T "emissions.energy"}}</th>
+				<th>{{$.T "pollutant.particulates"}}</th><th>{{$.T "pollutant.so2"}}</th><th>{{$.T "pollutant.nox"}}</th><th>{{$.T "pollutant.co2"}}</th>
+			</tr>
+			{{range .Lines}}
+			<tr>
+				<td>{{$.T .Fuel}}</td><td>{{$.Num.Format "quantity" .Quantity}} {{$.T (print "unit." .Unit)}}</td><td>{{$.Num.Format "energy" .Energy}}</td>
+				<td>{{$.Num.Format "total" .TotalEmission}}</td><td>{{$.Num.Format "total" .SO2.Total}}</td>
+				<td>{{$.Num.Format "total" .NOx.Total}}</td><td>{{$.Num.Format "total" .CO2.Total}}</td>
+			</tr>
+			{{end}}
+			{{with .Total}}
+			<tr>
+				<th colspan="2">{{$.T "emissions.sum"}}</th><th>{{$.Num.Format "energy" .Energy}}</th>
+				<th>{{$.Num.Format "total" .Particulates.Total}}</th><th>{{$.Num.Format "total" .SO2.Total}}</th>
+				<th>{{$.Num.Format "total" .NOx.Total}}</th><th>{{$.Num.Format "total" .CO2.Total}}</th>
+			</tr>
+			<tr>
+				<td colspan="3">{{$.T "emissions.factor"}}</td>
+				<td>{{$.Num.Format "factor" .Particulates.Factor}}</td><td>{{$.Num.Format "factor" .SO2.Factor}}</td>
+				<td>{{$.Num.Format "factor" .NOx.Factor}}</td><td>{{$.Num.Format "factor" .CO2.Factor}}</td>
+			</tr>
+			{{end}}
+		</table>
+		{{range .Lines}}
+		<p>{{$.T .Fuel}} — {{with .Conditions}}{{$.T (print "boiler." .Boiler)}}: a<sub>вин</sub> = {{$.Num.Format "fly_ash" .FlyAsh}}, Г<sub>вин</sub> = {{$.Num.Format "combustible_in_ash" .CombustibleInAsh}}.
+		{{$.T (print "abatement." .Abatement)}}: η = {{$.Num.Format "efficiency" .Efficiency}}, η<sub>SO₂</sub> = {{$.Num.Format "efficiency" .SO2Efficiency}}.{{end}}</p>
+		{{end}}
+		{{template "tax" $.Tax}}
+		{{range $.Checks}}{{template "checks" .}}{{end}}
 		{{end}}
 	</div>
 </body>
 </html>
 `))
-
-func main() {
-	http.HandleFunc("/", formHandler)
-	fmt.Println("Сервер запущено на :8080")
-	http.ListenAndServe(":8080", nil)
-}
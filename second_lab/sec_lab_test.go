@@ -0,0 +1,58 @@
+package secondlab
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"Go_tutor/ledger"
+)
+
+func postForm(t *testing.T, plant string) string {
+	t.Helper()
+	form := url.Values{
+		"lines.0.fuel":     {"Донецьке газове ГР"},
+		"lines.0.quantity": {"1000"},
+		"lines.0.unit":     {"t"},
+		"plant":            {plant},
+	}
+	r := httptest.NewRequest(http.MethodPost, "/?lang=en", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	FormHandler(w, r)
+	return w.Body.String()
+}
+
+func TestFormHandlerPermitCheck(t *testing.T) {
+	const plant = "Test TPP"
+	if _, err := ledger.Default.PutPermit(ledger.Permit{Plant: plant, Limits: []ledger.Limit{{Pollutant: "so2", Annual: 1000}}}); err != nil {
+		t.Fatal(err)
+	}
+	defer ledger.Default.DeletePermit(plant)
+
+	tests := []struct {
+		plant   string
+		section bool
+		notice  bool
+	}{
+		{plant, true, false},
+		// Станцію не обрано — перевірки й повідомлення немає
+		{"", false, false},
+		// Дозвіл видалено після завантаження форми
+		{"Unknown TPP", false, true},
+	}
+	for _, tt := range tests {
+		body := postForm(t, tt.plant)
+		if !strings.Contains(body, "Results") {
+			t.Fatalf("plant %q: no results in page", tt.plant)
+		}
+		if got := strings.Contains(body, "<h3>Permit check"); got != tt.section {
+			t.Errorf("plant %q: compliance section shown = %v, want %v", tt.plant, got, tt.section)
+		}
+		if got := strings.Contains(body, "permit not found"); got != tt.notice {
+			t.Errorf("plant %q: no-permit notice shown = %v, want %v", tt.plant, got, tt.notice)
+		}
+	}
+}
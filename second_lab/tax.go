@@ -0,0 +1,159 @@
+package secondlab
+
+import (
+	"html/template"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"Go_tutor/emissions"
+	"Go_tutor/i18n"
+	"Go_tutor/number"
+	"Go_tutor/tax"
+	"Go_tutor/validate"
+)
+
+// Таблиця екологічного податку, що виконується для taxView.
+const taxTemplate = `
+{{define "tax"}}
+{{with .Tax}}
+<h3>{{$.T "tax.heading" .Year}}</h3>
+<table>
+	<tr><th>{{$.T "emissions.pollutant"}}</th><th>{{$.T "emissions.gross"}}</th><th>{{$.T "tax.rate"}}</th><th>{{$.T "tax.amount"}}</th></tr>
+	{{range .Items}}
+	<tr>
+		<td>{{$.T (print "pollutant." .Pollutant)}}</td><td>{{$.Num.Format "total" .Emission}}</td>
+		<td>{{$.Num.Format "rate" .Rate}}</td><td>{{$.Num.Format "amount" .Amount}}</td>
+	</tr>
+	{{end}}
+	<tr><th colspan="3">{{$.T "emissions.sum"}}</th><th>{{$.Num.Format "amount" .Total}}</th></tr>
+</table>
+<p>{{$.T "tax.rate_year" .RateYear}}</p>
+{{end}}
+{{end}}
+`
+
+// taxView — податок для шаблону "tax".
+type taxView struct {
+	i18n.Localizer
+	Num number.Formatter
+	Tax *tax.Tax
+}
+
+// Дані сторінки ставок податку
+type taxPage struct {
+	i18n.Localizer
+	Form       *validate.Form
+	Num        number.Formatter
+	Schedules  []tax.Schedule
+	Pollutants []string
+}
+
+// TaxHandler показує ставки екологічного податку за роками та дозволяє їх
+// змінювати.
+func TaxHandler(w http.ResponseWriter, r *http.Request) {
+	loc := i18n.FromRequest(w, r)
+	data := taxPage{
+		Localizer:  loc,
+		Num:        loc.Formatter(r, resultSpecs),
+		Pollutants: emissions.Pollutants(),
+		Form:       validate.Prefilled(url.Values{}),
+	}
+
+	// Заповнюємо форму ставок, обраних для редагування
+	if year, err := strconv.Atoi(r.URL.Query().Get("edit")); err == nil {
+		if s, ok := tax.Default.Get(year); ok {
+			values := url.Values{"year": {strconv.Itoa(s.Year)}}
+			for p, rate := range s.Rates {
+				values.Set("rates."+p, formatFloat(rate))
+			}
+			data.Form = validate.Prefilled(values)
+		}
+	}
+
+	if r.Method == http.MethodPost {
+		form := loc.Form(r)
+		if year := r.FormValue("delete"); year != "" {
+			n, _ := strconv.Atoi(year)
+			if err := tax.Default.Delete(n); err != nil {
+				form.Fail(err)
+			}
+		} else {
+			// Порожнє поле означає, що речовина не оподатковується
+			s := tax.Schedule{Year: form.Int("year"), Rates: map[string]float64{}}
+			for _, p := range data.Pollutants {
+				if rate := form.OptionalFloat("rates." + p); rate != nil {
+					s.Rates[p] = *rate
+				}
+			}
+			if form.Valid() {
+				if _, err := tax.Default.Put(s); err != nil {
+					form.Fail(err)
+				}
+			}
+		}
+		if form.Valid() {
+			// Відносна адреса, бо обробник змонтовано під префіксом
+			w.Header().Set("Location", "tax")
+			w.WriteHeader(http.StatusSeeOther)
+			return
+		}
+		data.Form = form
+	}
+
+	data.Schedules = tax.Default.List()
+	taxTmpl.Execute(w, data)
+}
+
+var taxTmpl = template.Must(template.New("tax").Parse(`
+<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+	<meta charset="UTF-8">
+	<title>{{.T "tax.title"}}</title>
+	<style>
+		body { font-family: Arial, sans-serif; background-color: #f4f4f4; text-align: center; padding: 20px; }
+		.container { background: white; padding: 20px; border-radius: 8px; box-shadow: 0px 0px 10px rgba(0, 0, 0, 0.1); width: 70%; margin: auto; }
+		table { border-collapse: collapse; margin: 10px auto; }
+		th, td { border: 1px solid #ccc; padding: 6px 10px; }
+		input, select { padding: 6px; margin: 5px; border-radius: 5px; border: 1px solid #ccc; }
+		.error { color: #dc3545; font-size: 0.9em; }
+	</style>
+</head>
+<body>
+	{{.Switcher}}
+	<div class="container">
+		<h1>{{.T "tax.title"}}</h1>
+		<table>
+			<tr>
+				<th>{{.T "ledger.year"}}</th>
+				{{range .Pollutants}}<th>{{$.T (print "pollutant." .)}}</th>{{end}}
+				<th></th>
+			</tr>
+			{{range $s := .Schedules}}
+			<tr>
+				<td>{{.Year}}</td>
+				{{range $.Pollutants}}<td>{{with index $s.Rates .}}{{$.Num.Format "rate" .}}{{else}}—{{end}}</td>{{end}}
+				<td>
+					<a href="?edit={{.Year}}">{{$.T "catalog.edit"}}</a>
+					<form method="POST" style="display:inline"><button type="submit" name="delete" value="{{.Year}}">{{$.T "catalog.delete"}}</button></form>
+				</td>
+			</tr>
+			{{end}}
+		</table>
+		<p>{{.T "tax.note"}}</p>
+		{{with .Form.Error ""}}<p class="error">{{.}}</p>{{end}}
+
+		<h2>{{.T "tax.save"}}</h2>
+		<form method="POST" action="tax">
+			<label>{{.T "ledger.year"}}: <input type="text" name="year" size="4" value="{{.Form.Value "year"}}"></label>{{with .Form.Error "year"}}<span class="error">{{.}}</span>{{end}}<br>
+			{{range .Pollutants}}{{$field := print "rates." .}}
+			<label>{{$.T (print "pollutant." .)}}, {{$.T "unit.uah_per_t"}}: <input type="text" name="{{$field}}" value="{{$.Form.Value $field}}"></label>{{with $.Form.Error $field}}<span class="error">{{.}}</span>{{end}}<br>
+			{{end}}
+			<input type="submit" value="{{.T "tax.save"}}">
+		</form>
+		<p><a href="./">{{.T "common.back"}}</a></p>
+	</div>
+</body>
+</html>
+`))
@@ -0,0 +1,65 @@
+// Пакет shortcircuit розраховує початковий струм трифазного короткого
+// замикання в іменованих та відносних одиницях.
+package shortcircuit
+
+import (
+	"math"
+
+	"Go_tutor/validate"
+)
+
+// Вхідні дані
+type Input struct {
+	Unom float64 `json:"unom"` // Середня номінальна напруга (кВ)
+	Sk   float64 `json:"sk"`   // Потужність КЗ системи (МВА)
+	Xc   float64 `json:"xc"`   // Опір системи (Ом)
+	Xt   float64 `json:"xt"`   // Опір трансформатора (Ом)
+	Sb   float64 `json:"sb"`   // Базисна потужність (МВА)
+}
+
+// Результати
+type Result struct {
+	XSum   float64 `json:"x_sum"`
+	Ik0    float64 `json:"ik0"`
+	XcPU   float64 `json:"xc_pu"`
+	XtPU   float64 `json:"xt_pu"`
+	XSumPU float64 `json:"x_sum_pu"`
+	Ik0PU  float64 `json:"ik0_pu"`
+}
+
+// Validate перевіряє вхідні дані.
+func (in Input) Validate() error {
+	errs := validate.Errors{}
+	errs.Positive("unom", in.Unom)
+	errs.Positive("sk", in.Sk)
+	errs.NonNegative("xc", in.Xc)
+	errs.NonNegative("xt", in.Xt)
+	errs.Positive("sb", in.Sb)
+	if in.Xc+in.Xt <= 0 {
+		errs.Add("xt", "total impedance Xc + Xt must be greater than zero")
+	}
+	return errs.Err()
+}
+
+// Calculate визначає сумарний опір та початковий струм КЗ.
+func Calculate(in Input) (Result, error) {
+	if err := in.Validate(); err != nil {
+		return Result{}, err
+	}
+
+	XSum := in.Xc + in.Xt
+	Ik0 := (in.Unom * 1000) / (math.Sqrt(3) * XSum)
+	XcPU := in.Xc * (in.Sb / in.Sk)
+	XtPU := in.Xt * (in.Sb / in.Sk)
+	XSumPU := XcPU + XtPU
+	Ib := in.Sb / (math.Sqrt(3) * in.Unom)
+	Ik0PU := Ik0 / Ib
+	return Result{
+		XSum:   XSum,
+		Ik0:    Ik0,
+		XcPU:   XcPU,
+		XtPU:   XtPU,
+		XSumPU: XSumPU,
+		Ik0PU:  Ik0PU,
+	}, nil
+}
@@ -1,127 +1,111 @@
-package main
+package sixlab
 
 import (
-	"encoding/json"
-	"fmt"
 	"html/template"
-	"log"
-	"math"
 	"net/http"
-)
-
-type InputData struct {
-	Name      string  `json:"name"`
-	Eta       float64 `json:"eta"`
-	CosPhi    float64 `json:"cos_phi"`
-	Voltage   float64 `json:"voltage"`
-	Count     int     `json:"count"`
-	Power     float64 `json:"power"`
-	UtilCoeff float64 `json:"util_coeff"`
-	TgPhi     float64 `json:"tg_phi"`
-	Kv        float64 `json:"kv"`
-}
 
-type ResultData struct {
-	Ip float64 `json:"Ip (Calculation Current in A)"`
-	Kv float64 `json:"Kv (Group Usage Coefficient)"`
-	Ne float64 `json:"Ne (Effective Number of EP)"`
-	Kr float64 `json:"Kr (Calculated Power Coefficient)"`
-	Pp float64 `json:"Pp (Calculated Active Load in kW)"`
-	Qp float64 `json:"Qp (Calculated Reactive Load in kVAr)"`
-	Sp float64 `json:"Sp (Total Power in kVA)"`
-	Ig float64 `json:"Ig (Calculated Group Current in A)"`
-}
-
-func calculateLoad(data InputData) (ResultData, error) {
-	if data.Voltage <= 0 || data.CosPhi <= 0 || data.Eta <= 0 || data.Power <= 0 || data.Count <= 0 {
-		return ResultData{}, fmt.Errorf("invalid input values, all numeric values must be positive")
-	}
+	"Go_tutor/api"
+	"Go_tutor/i18n"
+	"Go_tutor/load"
+)
 
-	pTotal := float64(data.Count) * data.Power
-	ip := (1000 * pTotal) / (math.Sqrt(3) * data.Voltage * data.CosPhi * data.Eta)
-	kvGroup := data.Kv
-	ne := math.Pow(float64(data.Count)*data.Power, 2) / (float64(data.Count) * math.Pow(data.Power, 2))
-	kr := 1.25
-	pp := kr * kvGroup * pTotal
-	qp := kr * kvGroup * pTotal * data.TgPhi
-	sp := math.Sqrt(pp*pp + qp*qp)
-	ig := (1000 * pp) / (math.Sqrt(3) * data.Voltage)
+var calculateLoad = api.Endpoint(load.Calculate)
 
-	return ResultData{
-		Ip: ip,
-		Kv: kvGroup,
-		Ne: ne,
-		Kr: kr,
-		Pp: pp,
-		Qp: qp,
-		Sp: sp,
-		Ig: ig,
-	}, nil
+// Ключі результатів у відповіді API, для яких показуються перекладені назви
+var resultKeys = []string{
+	"Ip (Calculation Current in A)",
+	"Kv (Group Usage Coefficient)",
+	"Ne (Effective Number of EP)",
+	"Kr (Calculated Power Coefficient)",
+	"Pp (Calculated Active Load in kW)",
+	"Qp (Calculated Reactive Load in kVAr)",
+	"Sp (Total Power in kVA)",
+	"Ig (Calculated Group Current in A)",
 }
 
-func handler(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Only POST method is supported", http.StatusMethodNotAllowed)
-		return
-	}
-
-	var input InputData
-	decoder := json.NewDecoder(r.Body)
-	if err := decoder.Decode(&input); err != nil {
-		http.Error(w, "Invalid input", http.StatusBadRequest)
-		return
-	}
-
-	result, err := calculateLoad(input)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(result); err != nil {
-		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
-	}
+func CalculateHandler(w http.ResponseWriter, r *http.Request) {
+	calculateLoad(w, r)
 }
 
-func indexHandler(w http.ResponseWriter, r *http.Request) {
+func IndexHandler(w http.ResponseWriter, r *http.Request) {
 	tmpl, err := template.New("index").Parse(`
-	<html>
-	<head><title>Electrical Load Calculator</title></head>
+	<html lang="{{.Lang}}">
+	<head><meta charset="UTF-8"><title>{{.T "load.title"}}</title><style>.error { color: red; }</style></head>
 	<body>
-		<h2>Enter Input Data</h2>
+		{{.Switcher}}
+		<h2>{{.T "common.enter_data"}}</h2>
 		<form id="calcForm">
-			<label>Count: <input type="number" name="count" required></label><br>
-			<label>Power (kW): <input type="number" step="any" name="power" required></label><br>
-			<label>Voltage (V): <input type="number" step="any" name="voltage" required></label><br>
-			<label>CosPhi: <input type="number" step="any" name="cos_phi" required></label><br>
-			<label>Eta: <input type="number" step="any" name="eta" required></label><br>
-			<label>Utilization Coeff.: <input type="number" step="any" name="util_coeff" required></label><br>
-			<label>TgPhi: <input type="number" step="any" name="tg_phi" required></label><br>
-			<label>Kv: <input type="number" step="any" name="kv" required></label><br>
-			<button type="submit">Calculate</button>
+			<label>{{.T "load.count"}}: <input type="text" name="count" required></label><span class="error" id="error-count"></span><br>
+			<label>{{.T "load.power"}}: <input type="text" name="power" required></label><span class="error" id="error-power"></span><br>
+			<label>{{.T "load.voltage"}}: <input type="text" name="voltage" required></label><span class="error" id="error-voltage"></span><br>
+			<label>{{.T "load.cos_phi"}}: <input type="text" name="cos_phi" required></label><span class="error" id="error-cos_phi"></span><br>
+			<label>{{.T "load.eta"}}: <input type="text" name="eta" required></label><span class="error" id="error-eta"></span><br>
+			<label>{{.T "load.util_coeff"}}: <input type="text" name="util_coeff" required></label><span class="error" id="error-util_coeff"></span><br>
+			<label>{{.T "load.tg_phi"}}: <input type="text" name="tg_phi" required></label><span class="error" id="error-tg_phi"></span><br>
+			<label>{{.T "load.kv"}}: <input type="text" name="kv" required></label><span class="error" id="error-kv"></span><br>
+			<p class="error" id="error-form"></p>
+			<button type="submit">{{.T "common.calculate"}}</button>
 		</form>
-		<h3>Result:</h3>
-		<table border="1" id="resultTable" style="display:none;">
-			<tr><th>Parameter</th><th>Value</th></tr>
-		</table>
+		<h3>{{.T "common.results"}}:</h3>
+		<table border="1" id="resultTable" style="display:none;"></table>
 		<script>
+		// Accepts both "0,7" and "0.7" as well as thousands separators.
+		function parseNumber(value) {
+			let s = value.trim().replace(/[\s\u00a0\u202f'’]/g, "");
+			const comma = s.lastIndexOf(","), dot = s.lastIndexOf(".");
+			if (comma >= 0 && dot >= 0) {
+				s = comma > dot ? s.replace(/\./g, "").replace(",", ".") : s.replace(/,/g, "");
+			} else if ((s.match(/,/g) || []).length > 1) {
+				s = s.replace(/,/g, "");
+			} else if ((s.match(/\./g) || []).length > 1) {
+				s = s.replace(/\./g, "");
+			} else {
+				s = s.replace(",", ".");
+			}
+			return s === "" ? NaN : Number(s);
+		}
+		const precision = Number(new URLSearchParams(window.location.search).get("precision") || 2);
+		const numberFormat = new Intl.NumberFormat({{.Lang}}, { minimumFractionDigits: precision, maximumFractionDigits: precision });
+		const labels = {{.Labels}};
+		const header = "<tr><th>" + {{.T "load.parameter"}} + "</th><th>" + {{.T "load.value"}} + "</th></tr>";
+		function showErrors(fields) {
+			document.querySelectorAll(".error").forEach(el => { el.textContent = ""; });
+			Object.keys(fields).forEach(key => {
+				const el = document.getElementById("error-" + key) || document.getElementById("error-form");
+				el.textContent = fields[key];
+			});
+		}
 		document.getElementById("calcForm").addEventListener("submit", function(event) {
 			event.preventDefault();
 			const formData = new FormData(event.target);
 			const data = {};
-			formData.forEach((value, key) => { data[key] = parseFloat(value) || 0; });
-			fetch("/calculate", {
+			const errors = {};
+			formData.forEach((value, key) => {
+				const number = parseNumber(value);
+				if (isNaN(number)) {
+					errors[key] = {{.T "must be a number"}};
+				}
+				data[key] = number;
+			});
+			showErrors(errors);
+			if (Object.keys(errors).length > 0) {
+				return;
+			}
+			fetch("calculate", {
 				method: "POST",
 				headers: { "Content-Type": "application/json" },
 				body: JSON.stringify(data)
 			})
 			.then(response => response.json())
 			.then(result => {
+				if (result.error) {
+					showErrors(result.error.fields || { "": result.error.message });
+					return;
+				}
 				const table = document.getElementById("resultTable");
-				table.innerHTML = "<tr><th>Parameter</th><th>Value</th></tr>";
+				table.innerHTML = header;
 				Object.keys(result).forEach(key => {
-					table.innerHTML += "<tr><td>" + key + "</td><td>" + parseFloat(result[key]).toFixed(2) + "</td></tr>";
+					table.innerHTML += "<tr><td>" + (labels[key] || key) + "</td><td>" + numberFormat.format(result[key]) + "</td></tr>";
 				});
 				table.style.display = "block";
 			})
@@ -136,17 +120,16 @@ func indexHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = tmpl.Execute(w, nil)
+	loc := i18n.FromRequest(w, r)
+	labels := make(map[string]string, len(resultKeys))
+	for _, key := range resultKeys {
+		labels[key] = loc.T(key)
+	}
+	err = tmpl.Execute(w, struct {
+		i18n.Localizer
+		Labels map[string]string
+	}{loc, labels})
 	if err != nil {
 		http.Error(w, "Template execution error", http.StatusInternalServerError)
 	}
 }
-
-func main() {
-	http.HandleFunc("/", indexHandler)
-	http.HandleFunc("/calculate", handler)
-	log.Println("Server started on :8080")
-	if err := http.ListenAndServe(":8080", nil); err != nil {
-		log.Fatalf("Server failed: %v", err)
-	}
-}
@@ -0,0 +1,188 @@
+package solar
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"Go_tutor/batch"
+	"Go_tutor/number"
+	"Go_tutor/stats"
+	"Go_tutor/validate"
+)
+
+// Sample — прогнозована та фактична генерація за одну годину, МВт.
+type Sample struct {
+	Forecast float64 `json:"forecast"`
+	Actual   float64 `json:"actual"`
+}
+
+// FitInput — погодинна історія прогнозу й генерації та параметри
+// розрахунку прибутку, як у Input.
+type FitInput struct {
+	Samples  []Sample `json:"samples"`
+	NewSigma *float64 `json:"new_sigma,omitempty"` // Покращене σ (МВт), за замовчуванням 0,25·σ
+	Band     *float64 `json:"band,omitempty"`      // Допустимий небаланс (% від прогнозу)
+	Tariff   *float64 `json:"tariff,omitempty"`    // Тариф (грн/кВт·год)
+}
+
+// Fit — оцінка розподілу похибки прогнозу за годинами з генерацією або
+// ненульовим прогнозом.
+type Fit struct {
+	Hours        int     `json:"hours"`         // Кількість врахованих годин
+	Pc           float64 `json:"pc"`            // Середня прогнозована потужність (МВт)
+	Bias         float64 `json:"bias"`          // Середня похибка факт − прогноз (МВт)
+	Sigma        float64 `json:"sigma"`         // Середньоквадратичне відхилення похибки (МВт)
+	Bandwidth    float64 `json:"bandwidth"`     // Ширина ядра ядерної оцінки (МВт)
+	HoursWithin  float64 `json:"hours_within"`  // Години з небалансом у межах допуску (%)
+	EnergyWithin float64 `json:"energy_within"` // Прогнозована енергія цих годин (%)
+	NormalShare  float64 `json:"normal_share"`  // Частка енергії в допуску за нормальним розподілом (%)
+	KDEShare     float64 `json:"kde_share"`     // Те саме за ядерною оцінкою (%)
+}
+
+// FitResult — оцінка похибки та прибуток і штраф за нормального й
+// емпіричного розподілів похибки.
+type FitResult struct {
+	Fit       Fit    `json:"fit"`
+	Normal    Result `json:"normal"`
+	Empirical Result `json:"empirical"`
+}
+
+// Validate перевіряє історію та параметри.
+func (in FitInput) Validate() error {
+	errs := validate.Errors{}
+	for i, s := range in.Samples {
+		errs.NonNegative(fmt.Sprintf("samples.%d.forecast", i), s.Forecast)
+		errs.NonNegative(fmt.Sprintf("samples.%d.actual", i), s.Actual)
+	}
+	if len(daylight(in.Samples)) < 2 {
+		errs.Add("samples", "at least two hours with generation are required")
+	}
+	if in.NewSigma != nil {
+		errs.Positive("new_sigma", *in.NewSigma)
+	}
+	if in.Band != nil {
+		errs.Positive("band", *in.Band)
+		errs.Percent("band", *in.Band)
+	}
+	if in.Tariff != nil {
+		errs.Positive("tariff", *in.Tariff)
+	}
+	return errs.Err()
+}
+
+// daylight відкидає нічні години, коли і прогноз, і генерація нульові:
+// вони не мають небалансу й завищили б точність прогнозу.
+func daylight(samples []Sample) []Sample {
+	var out []Sample
+	for _, s := range samples {
+		if s.Forecast != 0 || s.Actual != 0 {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// FitErrors оцінює розподіл похибки прогнозу за історією та рахує прибуток
+// і штраф для середньої прогнозованої потужності. Допуск кожної години —
+// Band відсотків її прогнозу; для моделей — Band відсотків Pc. Покращений
+// прогноз вважається незміщеним, а його похибки — пропорційно меншими.
+func FitErrors(in FitInput) (FitResult, error) {
+	if err := in.Validate(); err != nil {
+		return FitResult{}, err
+	}
+
+	samples := daylight(in.Samples)
+	band := DefaultBand
+	if in.Band != nil {
+		band = *in.Band
+	}
+	fit := Fit{Hours: len(samples)}
+	errs := make([]float64, len(samples))
+	var forecast, within float64
+	for i, s := range samples {
+		errs[i] = s.Actual - s.Forecast
+		fit.Pc += s.Forecast
+		forecast += s.Forecast
+		if math.Abs(errs[i]) <= band/100*s.Forecast {
+			fit.HoursWithin++
+			within += s.Forecast
+		}
+	}
+	fit.Pc /= float64(len(samples))
+	fit.HoursWithin *= 100 / float64(len(samples))
+	if forecast > 0 {
+		fit.EnergyWithin = within / forecast * 100
+	}
+	fit.Bias, fit.Sigma = stats.MeanStdDev(errs)
+	if fit.Sigma == 0 || fit.Pc == 0 {
+		return FitResult{}, validate.Errors{"samples": {Format: "forecast errors must vary and the forecast must be positive"}}
+	}
+
+	input := Input{Pc: fit.Pc, Delta: fit.Sigma / fit.Pc * 100, Sigma: &fit.Sigma, NewSigma: in.NewSigma, Band: &band, Tariff: in.Tariff}
+	c := input.conditions()
+	scale := c.NewSigma / fit.Sigma
+
+	// Генерація навколо прогнозу Pc з похибками історії
+	actual := make([]float64, len(errs))
+	improved := make([]float64, len(errs))
+	for i, e := range errs {
+		actual[i] = fit.Pc + e
+		improved[i] = fit.Pc + (e-fit.Bias)*scale
+	}
+	kde, err := stats.NewKDE(actual)
+	if err != nil {
+		return FitResult{}, err
+	}
+	newKDE, err := stats.NewKDE(improved)
+	if err != nil {
+		return FitResult{}, err
+	}
+	fit.Bandwidth = kde.Bandwidth
+
+	normal := stats.Normal{Mu: fit.Pc + fit.Bias, Sigma: fit.Sigma}
+	lo, hi := fit.Pc-c.Tolerance, fit.Pc+c.Tolerance
+	fit.NormalShare = stats.Probability(normal, lo, hi) * 100
+	fit.KDEShare = stats.Probability(kde, lo, hi) * 100
+
+	res := FitResult{Fit: fit}
+	c.Distribution = stats.KindNormal
+	res.Normal = result(input, c, normal, stats.Normal{Mu: fit.Pc, Sigma: c.NewSigma})
+	c.Distribution = stats.KindEmpirical
+	res.Empirical = result(input, c, kde, newKDE)
+	return res, nil
+}
+
+// ReadSamples читає погодинну історію з таблиці CSV або XLSX зі стовпцями
+// forecast та actual; решта стовпців (дата, година) ігнорується.
+func ReadSamples(data []byte) ([]Sample, error) {
+	t, err := batch.Read(data)
+	if err != nil {
+		return nil, err
+	}
+	columns := map[string]int{}
+	for i, name := range t.Header {
+		columns[name] = i
+	}
+	for _, name := range []string{"forecast", "actual"} {
+		if _, ok := columns[name]; !ok {
+			return nil, errors.New("the table must have forecast and actual columns")
+		}
+	}
+
+	samples := make([]Sample, 0, len(t.Rows))
+	for line, row := range t.Rows {
+		var s Sample
+		for name, dst := range map[string]*float64{"forecast": &s.Forecast, "actual": &s.Actual} {
+			v := ""
+			if i := columns[name]; i < len(row) {
+				v = row[i]
+			}
+			if *dst, err = number.Parse(v); err != nil {
+				return nil, fmt.Errorf("line %d: column %s: %w", line+2, name, err)
+			}
+		}
+		samples = append(samples, s)
+	}
+	return samples, nil
+}
@@ -0,0 +1,244 @@
+package solar
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"Go_tutor/stats"
+	"Go_tutor/validate"
+)
+
+// Hours — кількість інтервалів балансування за добу.
+const Hours = 24
+
+// Години сходу й заходу сонця для типової кривої ясного неба
+const (
+	Sunrise = 6.0
+	Sunset  = 18.0
+)
+
+// HourlyInput — погодинний прогноз генерації. Якщо Profile не задано,
+// використовується типова крива ясного неба із середньодобовою потужністю
+// Pc. Погодинні Sigma, NewSigma та Tariff задаються одним значенням для
+// всіх годин або 24 значеннями; без Sigma σ кожної години дорівнює
+// Delta відсоткам її прогнозу.
+type HourlyInput struct {
+	Pc           float64   `json:"pc,omitempty"`        // Середньодобова потужність для кривої ясного неба (МВт)
+	Profile      []float64 `json:"profile,omitempty"`   // Прогноз за годинами (МВт)
+	Delta        float64   `json:"delta,omitempty"`     // Похибка прогнозу (%)
+	Sigma        []float64 `json:"sigma,omitempty"`     // Поточне σ за годинами (МВт)
+	NewSigma     []float64 `json:"new_sigma,omitempty"` // Покращене σ за годинами (МВт), за замовчуванням 0,25·σ
+	Tariff       []float64 `json:"tariff,omitempty"`    // Тариф за годинами (грн/кВт·год)
+	Band         *float64  `json:"band,omitempty"`      // Допустимий небаланс (% від прогнозу години)
+	Distribution string    `json:"distribution,omitempty"`
+}
+
+// Hour — розрахунок однієї години.
+type Hour struct {
+	Hour      int      `json:"hour"`
+	Forecast  float64  `json:"forecast"`  // Прогноз (МВт)
+	Sigma     float64  `json:"sigma"`     // Поточне σ (МВт)
+	NewSigma  float64  `json:"new_sigma"` // Покращене σ (МВт)
+	Tolerance float64  `json:"tolerance"` // Допустимий небаланс (МВт)
+	Tariff    float64  `json:"tariff"`    // Тариф (грн/кВт·год)
+	Current   Scenario `json:"current"`
+	Improved  Scenario `json:"improved"`
+}
+
+// HourlyResult — погодинний розрахунок, підсумки за добу та для
+// порівняння розрахунок за середньодобовою потужністю. Якщо усереднені
+// дані не підходять для добового розрахунку (наприклад, для обраного
+// розподілу σ завелике відносно середньої потужності), Flat немає, а
+// FlatWarning пояснює чому.
+type HourlyResult struct {
+	Hours       []Hour          `json:"hours"`
+	Current     Scenario        `json:"current"`  // Частка енергії зважена за прогнозом
+	Improved    Scenario        `json:"improved"` // Те саме для покращеного прогнозу
+	Gain        float64         `json:"gain"`     // Прибуток після покращення прогнозу (тис. грн)
+	Flat        *Result         `json:"flat,omitempty"`
+	FlatWarning validate.Errors `json:"flat_warning,omitempty"`
+}
+
+// Validate перевіряє погодинні дані. Погодинне σ може бути нульовим лише
+// для годин без прогнозу.
+func (in HourlyInput) Validate() error {
+	errs := validate.Errors{}
+	profile := in.Profile
+	if len(profile) == 0 && in.Pc > 0 {
+		profile = ClearSky(in.Pc)
+	}
+	switch len(in.Profile) {
+	case 0:
+		errs.Positive("pc", in.Pc)
+	case Hours:
+		for i, p := range in.Profile {
+			errs.NonNegative(fmt.Sprintf("profile.%d", i), p)
+		}
+	default:
+		errs.Add("profile", "must have %d hourly values", Hours)
+	}
+	for field, values := range map[string][]float64{"sigma": in.Sigma, "new_sigma": in.NewSigma, "tariff": in.Tariff} {
+		if len(values) != 0 && len(values) != 1 && len(values) != Hours {
+			errs.Add(field, "must have one or %d hourly values", Hours)
+			continue
+		}
+		for i, v := range values {
+			night := field != "tariff" && len(values) == Hours && len(profile) == Hours && profile[i] == 0
+			if night && v == 0 {
+				continue
+			}
+			errs.Positive(fmt.Sprintf("%s.%d", field, i), v)
+		}
+	}
+	if len(in.Sigma) == 0 {
+		errs.Positive("delta", in.Delta)
+		errs.Percent("delta", in.Delta)
+	}
+	if in.Band != nil {
+		errs.Positive("band", *in.Band)
+		errs.Percent("band", *in.Band)
+	}
+	if in.Distribution != "" && !slices.Contains(stats.Kinds(), in.Distribution) {
+		errs.Add("distribution", stats.ErrUnknownKind.Error())
+	}
+	return errs.Err()
+}
+
+// ClearSky повертає типовий погодинний профіль ясного неба — синусоїду між
+// сходом і заходом сонця — із середньодобовою потужністю pc.
+func ClearSky(pc float64) []float64 {
+	profile := make([]float64, Hours)
+	sum := 0.0
+	for h := range profile {
+		t := float64(h) + 0.5
+		profile[h] = math.Max(0, math.Sin(math.Pi*(t-Sunrise)/(Sunset-Sunrise)))
+		sum += profile[h]
+	}
+	for h := range profile {
+		profile[h] *= pc * Hours / sum
+	}
+	return profile
+}
+
+// hourField повертає назву поля, з якого взято значення години h.
+func hourField(field string, values []float64, h int) string {
+	if len(values) == 1 {
+		h = 0
+	}
+	return fmt.Sprintf("%s.%d", field, h)
+}
+
+// hourly повертає значення години h: одне значення діє для всіх годин.
+func hourly(values []float64, h int) (float64, bool) {
+	switch len(values) {
+	case 0:
+		return 0, false
+	case 1:
+		return values[0], true
+	}
+	return values[h], true
+}
+
+// CalculateHourly рахує прибуток і штраф для кожної години доби та
+// підсумки за добу. Години без прогнозу не дають ні прибутку, ні штрафу.
+func CalculateHourly(in HourlyInput) (HourlyResult, error) {
+	if err := in.Validate(); err != nil {
+		return HourlyResult{}, err
+	}
+
+	profile := in.Profile
+	if len(profile) == 0 {
+		profile = ClearSky(in.Pc)
+	}
+	kind := in.Distribution
+	if kind == "" {
+		kind = stats.KindNormal
+	}
+	band := DefaultBand
+	if in.Band != nil {
+		band = *in.Band
+	}
+
+	var res HourlyResult
+	var energy, current, improved, sigma2 float64
+	for h, forecast := range profile {
+		hour := Hour{Hour: h, Forecast: forecast, Tariff: DefaultTariff, Tolerance: forecast * band / 100}
+		hour.Sigma = forecast * in.Delta / 100
+		if v, ok := hourly(in.Sigma, h); ok {
+			hour.Sigma = v
+		}
+		hour.NewSigma = hour.Sigma * DefaultImprovement
+		if v, ok := hourly(in.NewSigma, h); ok {
+			hour.NewSigma = v
+		}
+		if v, ok := hourly(in.Tariff, h); ok {
+			hour.Tariff = v
+		}
+		sigma2 += hour.Sigma * hour.Sigma
+
+		if forecast > 0 {
+			// Помилка вказує на поле, яке задав користувач: σ без
+			// погодинних значень визначається з похибки прогнозу, а
+			// покращене σ — з поточного
+			sigmaField := "delta"
+			if len(in.Sigma) > 0 {
+				sigmaField = hourField("sigma", in.Sigma, h)
+			}
+			newSigmaField := sigmaField
+			if len(in.NewSigma) > 0 {
+				newSigmaField = hourField("new_sigma", in.NewSigma, h)
+			}
+			dist, err := stats.FromMoments(kind, forecast, hour.Sigma)
+			if err != nil {
+				return HourlyResult{}, validate.Errors{sigmaField: {Format: err.Error()}}
+			}
+			newDist, err := stats.FromMoments(kind, forecast, hour.NewSigma)
+			if err != nil {
+				return HourlyResult{}, validate.Errors{newSigmaField: {Format: err.Error()}}
+			}
+			hour.Current = scenario(forecast, 1, dist, hour.Tolerance, hour.Tariff)
+			hour.Improved = scenario(forecast, 1, newDist, hour.Tolerance, hour.Tariff)
+		}
+
+		res.Current.Profit += hour.Current.Profit
+		res.Current.Penalty += hour.Current.Penalty
+		res.Improved.Profit += hour.Improved.Profit
+		res.Improved.Penalty += hour.Improved.Penalty
+		energy += forecast
+		current += forecast * hour.Current.EnergyShare
+		improved += forecast * hour.Improved.EnergyShare
+		res.Hours = append(res.Hours, hour)
+	}
+	if energy > 0 {
+		res.Current.EnergyShare = current / energy
+		res.Improved.EnergyShare = improved / energy
+	}
+	res.Gain = res.Improved.Profit - res.Improved.Penalty
+
+	// Для порівняння: середня потужність доби із середньоквадратичним σ
+	// годин і середнім тарифом, зваженим за прогнозом
+	pc := energy / Hours
+	flatSigma := math.Sqrt(sigma2 / Hours)
+	tariff := DefaultTariff
+	if energy > 0 {
+		tariff = 0
+		for _, hour := range res.Hours {
+			tariff += hour.Tariff * hour.Forecast / energy
+		}
+	}
+	flat := Input{Pc: pc, Sigma: &flatSigma, Band: &band, Tariff: &tariff, Distribution: in.Distribution}
+	if pc > 0 && flatSigma > 0 {
+		r, err := Calculate(flat)
+		if err == nil {
+			res.Flat = &r
+		} else if errs, ok := validate.Fields(err); ok {
+			res.FlatWarning = errs
+		} else {
+			res.FlatWarning = validate.Errors{}
+			res.FlatWarning.Add(validate.General, strings.ReplaceAll(err.Error(), "%", "%%"))
+		}
+	}
+	return res, nil
+}
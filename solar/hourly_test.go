@@ -0,0 +1,114 @@
+package solar
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"Go_tutor/stats"
+	"Go_tutor/validate"
+)
+
+func TestCalculateHourly(t *testing.T) {
+	res, err := CalculateHourly(HourlyInput{Pc: 5, Delta: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Hours) != Hours {
+		t.Fatalf("%d hours, want %d", len(res.Hours), Hours)
+	}
+	// Відносні σ і допуск однакові для всіх годин, тож частка енергії
+	// кожної денної години така сама, як у добовому розрахунку лаби
+	for _, h := range res.Hours {
+		if h.Forecast == 0 {
+			continue
+		}
+		if math.Abs(h.Current.EnergyShare-19.741265) > 1e-5 || math.Abs(h.Improved.EnergyShare-68.268949) > 1e-5 {
+			t.Errorf("hour %d: shares %g, %g", h.Hour, h.Current.EnergyShare, h.Improved.EnergyShare)
+		}
+	}
+	if res.Flat == nil {
+		t.Fatal("no flat comparison")
+	}
+	energy := 0.0
+	for _, h := range res.Hours {
+		energy += h.Forecast
+	}
+	if math.Abs(energy-5*Hours) > 1e-9 || math.Abs(res.Flat.Pc-5) > 1e-9 {
+		t.Errorf("energy %g, flat pc %g", energy, res.Flat.Pc)
+	}
+}
+
+func TestCalculateHourlyUnknownDistribution(t *testing.T) {
+	_, err := CalculateHourly(HourlyInput{Pc: 5, Delta: 20, Distribution: "gamma"})
+	errs, ok := validate.Fields(err)
+	if !ok || errs["distribution"].Format != stats.ErrUnknownKind.Error() {
+		t.Errorf("error = %v", err)
+	}
+	for _, kind := range stats.Kinds() {
+		if _, err := CalculateHourly(HourlyInput{Pc: 5, Delta: 20, Distribution: kind}); err != nil {
+			t.Errorf("%s: %v", kind, err)
+		}
+	}
+}
+
+// Усереднене σ однієї сонячної години завелике для бета-розподілу з
+// середньою потужністю доби, але погодинний розрахунок можливий.
+func TestCalculateHourlyWithoutFlat(t *testing.T) {
+	profile := make([]float64, Hours)
+	profile[12] = 24
+	res, err := CalculateHourly(HourlyInput{Profile: profile, Delta: 90, Distribution: stats.KindBeta})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Flat != nil {
+		t.Errorf("flat = %+v, want nil", res.Flat)
+	}
+	if res.FlatWarning["distribution"].Format != stats.ErrMoments.Error() {
+		t.Errorf("flat warning = %v", res.FlatWarning)
+	}
+	if res.Hours[12].Current.EnergyShare <= 0 {
+		t.Errorf("hour 12 share = %g", res.Hours[12].Current.EnergyShare)
+	}
+	_, err = Calculate(Input{Pc: 1, Sigma: &res.Hours[12].Sigma, Distribution: stats.KindBeta})
+	if !errors.As(err, new(validate.Errors)) {
+		t.Errorf("flat input unexpectedly valid: %v", err)
+	}
+}
+
+// Помилка розподілу вказує на поле, з якого взято σ години.
+func TestCalculateHourlyErrorField(t *testing.T) {
+	tests := []struct {
+		in    HourlyInput
+		field string
+	}{
+		{HourlyInput{Pc: 5, Delta: 100, Distribution: stats.KindBeta}, "delta"},
+		{HourlyInput{Pc: 5, Sigma: []float64{6}, Distribution: stats.KindBeta}, "sigma.0"},
+		{HourlyInput{Pc: 5, Delta: 20, NewSigma: []float64{6}, Distribution: stats.KindBeta}, "new_sigma.0"},
+	}
+	for _, tt := range tests {
+		_, err := CalculateHourly(tt.in)
+		errs, ok := validate.Fields(err)
+		if !ok || errs[tt.field].Format != stats.ErrMoments.Error() {
+			t.Errorf("%+v: error = %v, want field %q", tt.in, err, tt.field)
+		}
+	}
+}
+
+// Для годин без прогнозу σ може бути нульовим, для решти — ні.
+func TestHourlyZeroSigmaAtNight(t *testing.T) {
+	sigma := make([]float64, Hours)
+	for h, p := range ClearSky(5) {
+		if p > 0 {
+			sigma[h] = p / 5
+		}
+	}
+	if _, err := CalculateHourly(HourlyInput{Pc: 5, Sigma: sigma}); err != nil {
+		t.Fatal(err)
+	}
+	sigma[12] = 0
+	_, err := CalculateHourly(HourlyInput{Pc: 5, Sigma: sigma})
+	if errs, ok := validate.Fields(err); !ok || len(errs) != 1 || errs["sigma.12"].Format == "" {
+		t.Errorf("error = %v, want sigma.12", err)
+	}
+}
@@ -0,0 +1,139 @@
+package solar
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+
+	"Go_tutor/stats"
+	"Go_tutor/validate"
+)
+
+// Параметри моделювання Монте-Карло
+const (
+	DefaultDays   = 1000  // Кількість змодельованих діб за замовчуванням
+	MaxDays       = 10000 // Найбільша кількість діб
+	HistogramBins = 20    // Кількість інтервалів гістограми
+)
+
+// SimulationInput — параметри розрахунку, як у Input, та кількість діб
+// моделювання. Seed задає початкове значення генератора випадкових чисел
+// для відтворюваного результату; без нього воно обирається випадково.
+type SimulationInput struct {
+	Input
+	Days int    `json:"days,omitempty"`
+	Seed *int64 `json:"seed,omitempty"`
+}
+
+// Revenue — розподіл чистого доходу (прибуток − штраф) за добу, тис. грн.
+type Revenue struct {
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"std_dev"`
+	P10    float64 `json:"p10"`
+	P50    float64 `json:"p50"`
+	P90    float64 `json:"p90"`
+	// Ймовірність від'ємного значення (%)
+	Loss      float64     `json:"loss"`
+	Histogram []stats.Bin `json:"histogram"`
+}
+
+// Simulation — результат моделювання поряд з точковою оцінкою Calculate.
+// Difference — різниця доходу покращеного й поточного прогнозу за ту саму
+// добу; її Loss — ймовірність, що покращення не окупиться за добу.
+type Simulation struct {
+	Expected   Result  `json:"expected"`
+	Days       int     `json:"days"`
+	Seed       int64   `json:"seed"`
+	Current    Revenue `json:"current"`
+	Improved   Revenue `json:"improved"`
+	Difference Revenue `json:"difference"`
+}
+
+// Validate перевіряє параметри розрахунку та кількість діб.
+func (in SimulationInput) Validate() error {
+	errs := validate.Errors{}
+	if in.Days != 0 {
+		errs.Range("days", float64(in.Days), 1, MaxDays)
+	}
+	if err := in.Input.Validate(); err != nil {
+		fields, ok := validate.Fields(err)
+		if !ok {
+			return err
+		}
+		for field, m := range fields {
+			errs.Add(field, m.Format, m.Args...)
+		}
+	}
+	return errs.Err()
+}
+
+// Simulate моделює Days діб роботи станції. Генерація кожної години
+// вибирається з розподілу методом оберненого перетворення: година з
+// небалансом у межах допуску приносить Pc·B, поза ним — такий самий
+// штраф, як і в Calculate. Поточний і покращений прогноз моделюються на
+// тих самих випадкових числах, тож їхня різниця не містить зайвого шуму.
+func Simulate(in SimulationInput) (Simulation, error) {
+	if err := in.Validate(); err != nil {
+		return Simulation{}, err
+	}
+	days := in.Days
+	if days == 0 {
+		days = DefaultDays
+	}
+	seed := time.Now().UnixNano()
+	if in.Seed != nil {
+		seed = *in.Seed
+	}
+
+	c := in.conditions()
+	// Validate вже перевірив, що розподіли існують
+	dist, _ := stats.FromMoments(c.Distribution, in.Pc, c.Sigma)
+	newDist, _ := stats.FromMoments(c.Distribution, in.Pc, c.NewSigma)
+
+	rng := rand.New(rand.NewSource(seed))
+	hourly := in.Pc * c.Tariff // Дохід або штраф за годину, тис. грн
+	current := make([]float64, days)
+	improved := make([]float64, days)
+	difference := make([]float64, days)
+	for d := range days {
+		for range 24 {
+			u := rng.Float64()
+			current[d] += hourRevenue(dist.Quantile(u), in.Pc, c.Tolerance, hourly)
+			improved[d] += hourRevenue(newDist.Quantile(u), in.Pc, c.Tolerance, hourly)
+		}
+		difference[d] = improved[d] - current[d]
+	}
+
+	return Simulation{
+		Expected:   result(in.Input, c, dist, newDist),
+		Days:       days,
+		Seed:       seed,
+		Current:    revenue(current),
+		Improved:   revenue(improved),
+		Difference: revenue(difference),
+	}, nil
+}
+
+// hourRevenue повертає дохід години з генерацією actual при прогнозі pc.
+func hourRevenue(actual, pc, tolerance, hourly float64) float64 {
+	if actual >= pc-tolerance && actual <= pc+tolerance {
+		return hourly
+	}
+	return -hourly
+}
+
+// revenue підсумовує змодельовані значення доходу.
+func revenue(values []float64) Revenue {
+	sort.Float64s(values)
+	mean, sd := stats.MeanStdDev(values)
+	loss := sort.SearchFloat64s(values, 0)
+	return Revenue{
+		Mean:      mean,
+		StdDev:    sd,
+		P10:       stats.Percentile(values, 10),
+		P50:       stats.Percentile(values, 50),
+		P90:       stats.Percentile(values, 90),
+		Loss:      float64(loss) / float64(len(values)) * 100,
+		Histogram: stats.Histogram(values, HistogramBins),
+	}
+}
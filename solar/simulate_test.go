@@ -0,0 +1,86 @@
+package solar
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"Go_tutor/stats"
+)
+
+// Середній змодельований дохід збігається з точковою оцінкою Calculate в
+// межах чотирьох стандартних похибок.
+func TestSimulateConverges(t *testing.T) {
+	for _, kind := range stats.Kinds() {
+		seed := int64(1)
+		days := 4000
+		if kind == stats.KindBeta {
+			days = 1000
+		}
+		sim, err := Simulate(SimulationInput{Input: Input{Pc: 5, Delta: 20, Distribution: kind}, Days: days, Seed: &seed})
+		if err != nil {
+			t.Fatalf("%s: %v", kind, err)
+		}
+		e := sim.Expected
+		tests := []struct {
+			name string
+			r    Revenue
+			want float64
+		}{
+			{"current", sim.Current, e.Current.Profit - e.Current.Penalty},
+			{"improved", sim.Improved, e.Gain},
+			{"difference", sim.Difference, e.Gain - (e.Current.Profit - e.Current.Penalty)},
+		}
+		for _, tt := range tests {
+			if se := tt.r.StdDev / math.Sqrt(float64(days)); math.Abs(tt.r.Mean-tt.want) > 4*se {
+				t.Errorf("%s %s: mean %g, want %g ± %g", kind, tt.name, tt.r.Mean, tt.want, 4*se)
+			}
+			if !(tt.r.P10 <= tt.r.P50 && tt.r.P50 <= tt.r.P90) {
+				t.Errorf("%s %s: percentiles %g, %g, %g", kind, tt.name, tt.r.P10, tt.r.P50, tt.r.P90)
+			}
+			count := 0
+			for _, b := range tt.r.Histogram {
+				count += b.Count
+			}
+			if count != days {
+				t.Errorf("%s %s: histogram has %d days, want %d", kind, tt.name, count, days)
+			}
+		}
+	}
+}
+
+func TestSimulateSeed(t *testing.T) {
+	seed := int64(42)
+	in := SimulationInput{Input: Input{Pc: 5, Delta: 20}, Days: 500, Seed: &seed}
+	a, err := Simulate(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := Simulate(in)
+	if !reflect.DeepEqual(a, b) {
+		t.Error("the same seed gave different results")
+	}
+	if a.Seed != seed || a.Days != 500 {
+		t.Errorf("seed %d, days %d", a.Seed, a.Days)
+	}
+
+	other := int64(43)
+	in.Seed = &other
+	c, _ := Simulate(in)
+	if reflect.DeepEqual(a.Current.Histogram, c.Current.Histogram) {
+		t.Error("different seeds gave the same histogram")
+	}
+}
+
+func TestSimulateDefaults(t *testing.T) {
+	sim, err := Simulate(SimulationInput{Input: Input{Pc: 5, Delta: 20}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sim.Days != DefaultDays {
+		t.Errorf("days = %d, want %d", sim.Days, DefaultDays)
+	}
+	if _, err := Simulate(SimulationInput{Input: Input{Pc: 5, Delta: 20}, Days: MaxDays + 1}); err == nil {
+		t.Error("too many days accepted")
+	}
+}
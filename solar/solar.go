@@ -0,0 +1,155 @@
+// Пакет solar оцінює прибуток та штрафи сонячної електростанції залежно
+// від точності прогнозу генерації.
+package solar
+
+import (
+	"Go_tutor/stats"
+	"Go_tutor/validate"
+)
+
+// Параметри за замовчуванням
+const (
+	DefaultBand        = 5.0  // Допустимий небаланс, % від Pc
+	DefaultTariff      = 7.0  // Тариф, грн/кВт·год
+	DefaultImprovement = 0.25 // Відношення покращеного σ до поточного
+)
+
+// Вхідні дані. Необов'язкові параметри, не задані користувачем, беруться
+// за замовчуванням; поточне σ без явного значення визначається з похибки
+// прогнозу: σ = Pc·δ/100.
+type Input struct {
+	Pc       float64  `json:"pc"`                  // Середньодобова потужність (МВт)
+	Delta    float64  `json:"delta"`               // Похибка прогнозу (%)
+	Sigma    *float64 `json:"sigma,omitempty"`     // Поточне середньоквадратичне відхилення прогнозу (МВт)
+	NewSigma *float64 `json:"new_sigma,omitempty"` // Покращене середньоквадратичне відхилення (МВт)
+	Band     *float64 `json:"band,omitempty"`      // Допустимий небаланс (% від Pc)
+	Tariff   *float64 `json:"tariff,omitempty"`    // Тариф (грн/кВт·год)
+	// Розподіл генерації навколо прогнозу (stats.Kinds), за замовчуванням
+	// нормальний
+	Distribution string `json:"distribution,omitempty"`
+}
+
+// Conditions — параметри, фактично застосовані в розрахунку.
+type Conditions struct {
+	Sigma     float64 `json:"sigma"`     // Поточне σ (МВт)
+	NewSigma  float64 `json:"new_sigma"` // Покращене σ (МВт)
+	Band      float64 `json:"band"`      // Допустимий небаланс (%)
+	Tolerance float64 `json:"tolerance"` // Допустимий небаланс (МВт)
+	Tariff    float64 `json:"tariff"`    // Тариф (грн/кВт·год)
+	// Розподіл генерації навколо прогнозу
+	Distribution string `json:"distribution"`
+}
+
+// Енергія в межах допуску та відповідні прибуток і штраф
+type Scenario struct {
+	EnergyShare float64 `json:"energy_share"` // Частка енергії без небалансів (%)
+	Profit      float64 `json:"profit"`       // Прибуток (тис. грн)
+	Penalty     float64 `json:"penalty"`      // Штраф (тис. грн)
+}
+
+// Результати для поточного та покращеного прогнозу
+type Result struct {
+	Input
+	Conditions Conditions `json:"conditions"`
+	Current    Scenario   `json:"current"`
+	Improved   Scenario   `json:"improved"`
+	Gain       float64    `json:"gain"` // Прибуток після покращення прогнозу (тис. грн)
+}
+
+// Validate перевіряє потужність та похибку прогнозу.
+func (in Input) Validate() error {
+	errs := validate.Errors{}
+	errs.Positive("pc", in.Pc)
+	errs.Percent("delta", in.Delta)
+	if in.Sigma == nil {
+		errs.Positive("delta", in.Delta)
+	} else {
+		errs.Positive("sigma", *in.Sigma)
+	}
+	if in.NewSigma != nil {
+		errs.Positive("new_sigma", *in.NewSigma)
+	}
+	if in.Band != nil {
+		errs.Positive("band", *in.Band)
+		errs.Percent("band", *in.Band)
+	}
+	if in.Tariff != nil {
+		errs.Positive("tariff", *in.Tariff)
+	}
+	if len(errs) == 0 {
+		c := in.conditions()
+		for _, sigma := range []float64{c.Sigma, c.NewSigma} {
+			if _, err := stats.FromMoments(c.Distribution, in.Pc, sigma); err != nil {
+				errs.Add("distribution", err.Error())
+			}
+		}
+	}
+	return errs.Err()
+}
+
+// conditions підставляє значення за замовчуванням для незаданих параметрів.
+func (in Input) conditions() Conditions {
+	c := Conditions{
+		Sigma:        in.Pc * in.Delta / 100,
+		Band:         DefaultBand,
+		Tariff:       DefaultTariff,
+		Distribution: stats.KindNormal,
+	}
+	if in.Distribution != "" {
+		c.Distribution = in.Distribution
+	}
+	if in.Sigma != nil {
+		c.Sigma = *in.Sigma
+	}
+	c.NewSigma = c.Sigma * DefaultImprovement
+	if in.NewSigma != nil {
+		c.NewSigma = *in.NewSigma
+	}
+	if in.Band != nil {
+		c.Band = *in.Band
+	}
+	if in.Tariff != nil {
+		c.Tariff = *in.Tariff
+	}
+	c.Tolerance = in.Pc * c.Band / 100
+	return c
+}
+
+// Calculate рахує прибуток і штраф для поточної та покращеної системи прогнозу.
+func Calculate(in Input) (Result, error) {
+	if err := in.Validate(); err != nil {
+		return Result{}, err
+	}
+
+	c := in.conditions()
+	// Validate вже перевірив, що розподіли існують
+	dist, _ := stats.FromMoments(c.Distribution, in.Pc, c.Sigma)
+	newDist, _ := stats.FromMoments(c.Distribution, in.Pc, c.NewSigma)
+	return result(in, c, dist, newDist), nil
+}
+
+// result рахує прибуток і штраф для генерації з розподілами dist до та
+// newDist після покращення прогнозу.
+func result(in Input, c Conditions, dist, newDist stats.Distribution) Result {
+	current := scenario(in.Pc, 24, dist, c.Tolerance, c.Tariff)
+	improved := scenario(in.Pc, 24, newDist, c.Tolerance, c.Tariff)
+	return Result{
+		Input:      in,
+		Conditions: c,
+		Current:    current,
+		Improved:   improved,
+		Gain:       improved.Profit - improved.Penalty,
+	}
+}
+
+// scenario рахує частку енергії, для якої генерація з розподілом dist
+// відхиляється від прогнозу pc не більше ніж на tolerance, та прибуток і
+// штраф за hours годин.
+func scenario(pc, hours float64, dist stats.Distribution, tolerance, B float64) Scenario {
+	energyPercentage := stats.Probability(dist, pc-tolerance, pc+tolerance) * 100
+	return Scenario{
+		EnergyShare: energyPercentage,
+		Profit:      (pc * hours) * B * (energyPercentage / 100),
+		Penalty:     (pc * hours) * B * ((100 - energyPercentage) / 100),
+	}
+}
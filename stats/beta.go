@@ -0,0 +1,107 @@
+package stats
+
+import "math"
+
+// Beta — бета-розподіл з параметрами форми Alpha та Beta, перенесений на
+// інтервал [Min, Max].
+type Beta struct {
+	Alpha, Beta float64
+	Min, Max    float64
+}
+
+func (b Beta) PDF(x float64) float64 {
+	if x < b.Min || x > b.Max {
+		return 0
+	}
+	w := b.Max - b.Min
+	z := (x - b.Min) / w
+	// При Alpha = 1 чи Beta = 1 відповідний множник дорівнює одиниці і на
+	// краю інтервалу, де 0·ln 0 дало б NaN
+	logp := -lbeta(b.Alpha, b.Beta)
+	if b.Alpha != 1 {
+		logp += (b.Alpha - 1) * math.Log(z)
+	}
+	if b.Beta != 1 {
+		logp += (b.Beta - 1) * math.Log1p(-z)
+	}
+	return math.Exp(logp) / w
+}
+
+func (b Beta) CDF(x float64) float64 {
+	switch {
+	case x <= b.Min:
+		return 0
+	case x >= b.Max:
+		return 1
+	}
+	return incompleteBeta(b.Alpha, b.Beta, (x-b.Min)/(b.Max-b.Min))
+}
+
+func (b Beta) Quantile(p float64) float64 {
+	if q, ok := quantileBounds(p, b.Min, b.Max); ok {
+		return q
+	}
+	return invert(b.CDF, b.PDF, p, b.Min, b.Max)
+}
+
+func (b Beta) Mean() float64 {
+	return b.Min + (b.Max-b.Min)*b.Alpha/(b.Alpha+b.Beta)
+}
+
+func (b Beta) StdDev() float64 {
+	s := b.Alpha + b.Beta
+	return (b.Max - b.Min) * math.Sqrt(b.Alpha*b.Beta/(s*s*(s+1)))
+}
+
+func lbeta(a, b float64) float64 {
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	return la + lb - lab
+}
+
+// incompleteBeta — регуляризована неповна бета-функція I_x(a, b),
+// обчислена ланцюговим дробом.
+func incompleteBeta(a, b, x float64) float64 {
+	front := math.Exp(a*math.Log(x) + b*math.Log1p(-x) - lbeta(a, b))
+	// Дріб збігається швидко при x < (a+1)/(a+b+2), інакше використовуємо
+	// симетрію I_x(a, b) = 1 − I_{1−x}(b, a)
+	if x < (a+1)/(a+b+2) {
+		return front * betaFraction(a, b, x) / a
+	}
+	return 1 - front*betaFraction(b, a, 1-x)/b
+}
+
+// betaFraction обчислює ланцюговий дріб для неповної бета-функції методом
+// Лентца.
+func betaFraction(a, b, x float64) float64 {
+	const tiny = 1e-300
+	c, d := 1.0, 1-(a+b)*x/(a+1)
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+	for m := 1; m <= 300; m++ {
+		fm := float64(m)
+		for _, num := range [2]float64{
+			fm * (b - fm) * x / ((a + 2*fm - 1) * (a + 2*fm)),
+			-(a + fm) * (a + b + fm) * x / ((a + 2*fm) * (a + 2*fm + 1)),
+		} {
+			d = 1 + num*d
+			if math.Abs(d) < tiny {
+				d = tiny
+			}
+			c = 1 + num/c
+			if math.Abs(c) < tiny {
+				c = tiny
+			}
+			d = 1 / d
+			h *= d * c
+		}
+		if math.Abs(d*c-1) < 1e-15 {
+			break
+		}
+	}
+	return h
+}
@@ -0,0 +1,67 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+// Для цілих параметрів I_x(a, b) виражається через біноміальний розподіл,
+// для a = b = 1/2 — через арксинус, тож значення перевіряються точно.
+func TestBeta(t *testing.T) {
+	b25 := Beta{Alpha: 2, Beta: 5, Min: 0, Max: 1}
+	check(t, "PDF", b25.PDF, []point{
+		{"B(2,5)", 0.2, 2.4576},
+		{"B(2,5)", 0, 0},
+		{"B(2,5)", 1, 0},
+	})
+	check(t, "CDF", b25.CDF, []point{
+		{"B(2,5)", 0.2, 0.34464},
+		{"B(2,5)", 0.5, 0.890625},
+	})
+	check(t, "Quantile", b25.Quantile, []point{
+		{"B(2,5)", 0.34464, 0.2},
+		{"B(2,5)", 0.890625, 0.5},
+	})
+
+	arcsine := Beta{Alpha: 0.5, Beta: 0.5, Min: 0, Max: 1}
+	check(t, "PDF", arcsine.PDF, []point{{"B(1/2,1/2)", 0.5, 2 / math.Pi}})
+	check(t, "CDF", arcsine.CDF, []point{{"B(1/2,1/2)", 0.25, 1.0 / 3}})
+	check(t, "Quantile", arcsine.Quantile, []point{{"B(1/2,1/2)", 1.0 / 3, 0.25}})
+
+	// Перенесений на [-1, 1] розподіл B(2, 2): 3/4·(1 − x²)
+	b22 := Beta{Alpha: 2, Beta: 2, Min: -1, Max: 1}
+	check(t, "PDF", b22.PDF, []point{{"B(2,2)", 0, 0.75}, {"B(2,2)", 0.5, 0.5625}})
+	check(t, "CDF", b22.CDF, []point{{"B(2,2)", 0, 0.5}, {"B(2,2)", 0.5, 0.84375}})
+}
+
+// При Alpha = 1 чи Beta = 1 щільність на краю інтервалу скінченна.
+func TestBetaPDFEndpoints(t *testing.T) {
+	uniform := Beta{Alpha: 1, Beta: 1, Min: 0, Max: 10}
+	check(t, "PDF", uniform.PDF, []point{
+		{"B(1,1)", 0, 0.1},
+		{"B(1,1)", 5, 0.1},
+		{"B(1,1)", 10, 0.1},
+	})
+	check(t, "CDF", uniform.CDF, []point{{"B(1,1)", 2.5, 0.25}})
+	check(t, "Quantile", uniform.Quantile, []point{{"B(1,1)", 0.3, 3}})
+
+	check(t, "PDF", Beta{Alpha: 1, Beta: 3, Min: 0, Max: 1}.PDF, []point{
+		{"B(1,3)", 0, 3},
+		{"B(1,3)", 1, 0},
+	})
+	check(t, "PDF", Beta{Alpha: 3, Beta: 1, Min: 0, Max: 1}.PDF, []point{
+		{"B(3,1)", 1, 3},
+		{"B(3,1)", 0, 0},
+	})
+
+	// sd = mean/√3 дає рівномірний розподіл на [0, 2·mean]
+	d, err := FromMoments(KindBeta, 5, 5/math.Sqrt(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, x := range []float64{0, 10} {
+		if p := d.PDF(x); math.IsNaN(p) {
+			t.Errorf("FromMoments beta: PDF(%g) = NaN", x)
+		}
+	}
+}
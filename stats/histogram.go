@@ -0,0 +1,35 @@
+package stats
+
+import "math"
+
+// Bin — інтервал гістограми [From, To) та кількість значень у ньому.
+type Bin struct {
+	From  float64 `json:"from"`
+	To    float64 `json:"to"`
+	Count int     `json:"count"`
+}
+
+// Histogram розбиває упорядковану вибірку на bins однакових інтервалів від
+// найменшого до найбільшого значення; найбільше значення потрапляє в
+// останній інтервал. Вибірка з однакових значень дає один інтервал.
+func Histogram(sorted []float64, bins int) []Bin {
+	if len(sorted) == 0 || bins < 1 {
+		return nil
+	}
+	lo, hi := sorted[0], sorted[len(sorted)-1]
+	if hi == lo {
+		return []Bin{{From: lo, To: hi, Count: len(sorted)}}
+	}
+	width := (hi - lo) / float64(bins)
+	out := make([]Bin, bins)
+	for i := range out {
+		out[i].From = lo + float64(i)*width
+		out[i].To = lo + float64(i+1)*width
+	}
+	out[bins-1].To = hi
+	for _, x := range sorted {
+		i := int(math.Floor((x - lo) / width))
+		out[min(max(i, 0), bins-1)].Count++
+	}
+	return out
+}
@@ -0,0 +1,20 @@
+package stats
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHistogram(t *testing.T) {
+	got := Histogram([]float64{0, 1, 1, 2, 3, 4}, 4)
+	want := []Bin{{0, 1, 1}, {1, 2, 2}, {2, 3, 1}, {3, 4, 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Histogram = %v, want %v", got, want)
+	}
+	if got := Histogram([]float64{5, 5, 5}, 10); !reflect.DeepEqual(got, []Bin{{5, 5, 3}}) {
+		t.Errorf("constant sample: %v", got)
+	}
+	if got := Histogram(nil, 10); got != nil {
+		t.Errorf("empty sample: %v", got)
+	}
+}
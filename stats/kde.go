@@ -0,0 +1,109 @@
+package stats
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// ErrTooFewPoints повертається, якщо вибірка замала для оцінки розподілу.
+var ErrTooFewPoints = errors.New("at least two points are required")
+
+// KDE — ядерна оцінка щільності за вибіркою Points з нормальним ядром
+// ширини Bandwidth.
+type KDE struct {
+	Points    []float64
+	Bandwidth float64
+}
+
+// NewKDE будує ядерну оцінку щільності з шириною ядра за правилом
+// Сільвермана.
+func NewKDE(points []float64) (KDE, error) {
+	if len(points) < 2 {
+		return KDE{}, ErrTooFewPoints
+	}
+	sorted := append([]float64(nil), points...)
+	sort.Float64s(sorted)
+	_, sd := MeanStdDev(sorted)
+	iqr := Percentile(sorted, 75) - Percentile(sorted, 25)
+	spread := sd
+	if iqr > 0 && iqr/1.34 < spread {
+		spread = iqr / 1.34
+	}
+	h := 0.9 * spread * math.Pow(float64(len(sorted)), -0.2)
+	if h == 0 {
+		// Усі точки однакові: беремо вузьке ядро, щоб щільність існувала
+		h = 1e-9 * math.Max(1, math.Abs(sorted[0]))
+	}
+	return KDE{Points: sorted, Bandwidth: h}, nil
+}
+
+func (k KDE) PDF(x float64) float64 {
+	sum := 0.0
+	for _, p := range k.Points {
+		sum += Normal{p, k.Bandwidth}.PDF(x)
+	}
+	return sum / float64(len(k.Points))
+}
+
+func (k KDE) CDF(x float64) float64 {
+	sum := 0.0
+	for _, p := range k.Points {
+		sum += Normal{p, k.Bandwidth}.CDF(x)
+	}
+	return sum / float64(len(k.Points))
+}
+
+func (k KDE) Quantile(p float64) float64 {
+	if q, ok := quantileBounds(p, math.Inf(-1), math.Inf(1)); ok {
+		return q
+	}
+	span := 10 * k.Bandwidth
+	return invert(k.CDF, k.PDF, p, k.Points[0]-span, k.Points[len(k.Points)-1]+span)
+}
+
+func (k KDE) Mean() float64 {
+	m, _ := MeanStdDev(k.Points)
+	return m
+}
+
+// StdDev враховує і розкид точок, і ширину ядра.
+func (k KDE) StdDev() float64 {
+	_, sd := MeanStdDev(k.Points)
+	return math.Sqrt(sd*sd + k.Bandwidth*k.Bandwidth)
+}
+
+// MeanStdDev повертає середнє та вибіркове середньоквадратичне відхилення.
+func MeanStdDev(xs []float64) (mean, sd float64) {
+	if len(xs) == 0 {
+		return 0, 0
+	}
+	for _, x := range xs {
+		mean += x
+	}
+	mean /= float64(len(xs))
+	if len(xs) < 2 {
+		return mean, 0
+	}
+	for _, x := range xs {
+		sd += (x - mean) * (x - mean)
+	}
+	return mean, math.Sqrt(sd / float64(len(xs)-1))
+}
+
+// Percentile повертає p-й процентиль упорядкованої вибірки з лінійною
+// інтерполяцією між сусідніми точками.
+func Percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return math.NaN()
+	}
+	pos := p / 100 * float64(len(sorted)-1)
+	i := int(math.Floor(pos))
+	if i >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	if i < 0 {
+		return sorted[0]
+	}
+	return sorted[i] + (pos-float64(i))*(sorted[i+1]-sorted[i])
+}
@@ -0,0 +1,76 @@
+package stats
+
+import (
+	"errors"
+	"math"
+)
+
+// Види розподілів
+const (
+	KindNormal    = "normal"
+	KindLogNormal = "lognormal"
+	KindWeibull   = "weibull"
+	KindBeta      = "beta"
+	// Ядерна оцінка за вибіркою; не задається середнім і відхиленням
+	KindEmpirical = "empirical"
+)
+
+var (
+	ErrUnknownKind = errors.New("unknown distribution")
+	ErrMoments     = errors.New("the distribution cannot have this mean and standard deviation")
+)
+
+// Kinds повертає види розподілів, які можна задати середнім і
+// відхиленням.
+func Kinds() []string {
+	return []string{KindNormal, KindLogNormal, KindWeibull, KindBeta}
+}
+
+// FromMoments створює розподіл виду kind із середнім mean та
+// середньоквадратичним відхиленням sd. Логнормальний розподіл і розподіл
+// Вейбулла вимагають додатного середнього; бета-розподіл задається на
+// [0, 2·mean], тому sd має бути менше mean.
+func FromMoments(kind string, mean, sd float64) (Distribution, error) {
+	if sd <= 0 {
+		return nil, ErrMoments
+	}
+	switch kind {
+	case KindNormal:
+		return Normal{Mu: mean, Sigma: sd}, nil
+	case KindLogNormal:
+		if mean <= 0 {
+			return nil, ErrMoments
+		}
+		s2 := math.Log1p(sd * sd / (mean * mean))
+		return LogNormal{Mu: math.Log(mean) - s2/2, Sigma: math.Sqrt(s2)}, nil
+	case KindWeibull:
+		if mean <= 0 {
+			return nil, ErrMoments
+		}
+		// Коефіцієнт варіації спадає з ростом форми k
+		cv := sd / mean
+		lo, hi := math.Log(0.05), math.Log(2000)
+		if cv > weibullCV(math.Exp(lo)) || cv < weibullCV(math.Exp(hi)) {
+			return nil, ErrMoments
+		}
+		for range 100 {
+			m := (lo + hi) / 2
+			if weibullCV(math.Exp(m)) > cv {
+				lo = m
+			} else {
+				hi = m
+			}
+		}
+		k := math.Exp((lo + hi) / 2)
+		return Weibull{K: k, Lambda: mean / math.Gamma(1+1/k)}, nil
+	case KindBeta:
+		if mean <= 0 || sd >= mean {
+			return nil, ErrMoments
+		}
+		// Симетричний бета-розподіл на [0, 2·mean]: дисперсія w²/(4(2α+1))
+		w := 2 * mean
+		alpha := (w*w/(4*sd*sd) - 1) / 2
+		return Beta{Alpha: alpha, Beta: alpha, Min: 0, Max: w}, nil
+	}
+	return nil, ErrUnknownKind
+}
@@ -0,0 +1,42 @@
+package stats
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestFromMoments(t *testing.T) {
+	for _, kind := range Kinds() {
+		for _, m := range [][2]float64{{5, 1}, {5, 0.25}, {100, 30}} {
+			d, err := FromMoments(kind, m[0], m[1])
+			if err != nil {
+				t.Errorf("%s(%g, %g): %v", kind, m[0], m[1], err)
+				continue
+			}
+			if math.Abs(d.Mean()-m[0]) > 1e-9*m[0] || math.Abs(d.StdDev()-m[1]) > 1e-6*m[1] {
+				t.Errorf("%s(%g, %g): mean %g, sd %g", kind, m[0], m[1], d.Mean(), d.StdDev())
+			}
+		}
+	}
+}
+
+func TestFromMomentsErrors(t *testing.T) {
+	tests := []struct {
+		kind     string
+		mean, sd float64
+		want     error
+	}{
+		{KindNormal, 5, 0, ErrMoments},
+		{KindLogNormal, -1, 1, ErrMoments},
+		{KindWeibull, 0, 1, ErrMoments},
+		{KindBeta, 5, 5, ErrMoments},
+		{"gamma", 5, 1, ErrUnknownKind},
+		{KindEmpirical, 5, 1, ErrUnknownKind},
+	}
+	for _, tt := range tests {
+		if _, err := FromMoments(tt.kind, tt.mean, tt.sd); !errors.Is(err, tt.want) {
+			t.Errorf("%s(%g, %g): error %v, want %v", tt.kind, tt.mean, tt.sd, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package stats
+
+import "math"
+
+// Normal — нормальний розподіл із середнім Mu та відхиленням Sigma.
+type Normal struct {
+	Mu, Sigma float64
+}
+
+func (n Normal) PDF(x float64) float64 {
+	z := (x - n.Mu) / n.Sigma
+	return math.Exp(-z*z/2) / (n.Sigma * math.Sqrt(2*math.Pi))
+}
+
+func (n Normal) CDF(x float64) float64 {
+	return math.Erfc(-(x-n.Mu)/(n.Sigma*math.Sqrt2)) / 2
+}
+
+func (n Normal) Quantile(p float64) float64 {
+	if q, ok := quantileBounds(p, math.Inf(-1), math.Inf(1)); ok {
+		return q
+	}
+	return n.Mu - n.Sigma*math.Sqrt2*math.Erfcinv(2*p)
+}
+
+func (n Normal) Mean() float64   { return n.Mu }
+func (n Normal) StdDev() float64 { return n.Sigma }
+
+// LogNormal — логнормальний розподіл: ln X має нормальний розподіл із
+// параметрами Mu та Sigma.
+type LogNormal struct {
+	Mu, Sigma float64
+}
+
+func (l LogNormal) PDF(x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	return Normal{l.Mu, l.Sigma}.PDF(math.Log(x)) / x
+}
+
+func (l LogNormal) CDF(x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	return Normal{l.Mu, l.Sigma}.CDF(math.Log(x))
+}
+
+func (l LogNormal) Quantile(p float64) float64 {
+	return math.Exp(Normal{l.Mu, l.Sigma}.Quantile(p))
+}
+
+func (l LogNormal) Mean() float64 { return math.Exp(l.Mu + l.Sigma*l.Sigma/2) }
+
+func (l LogNormal) StdDev() float64 {
+	s2 := l.Sigma * l.Sigma
+	return math.Sqrt(math.Expm1(s2)) * l.Mean()
+}
@@ -0,0 +1,74 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+// point — табличне значення функції розподілу в точці.
+type point struct {
+	name string
+	x    float64
+	want float64
+}
+
+func check(t *testing.T, fn string, f func(float64) float64, points []point) {
+	t.Helper()
+	for _, p := range points {
+		got := f(p.x)
+		if math.IsNaN(got) || math.Abs(got-p.want) > 1e-9*math.Max(1, math.Abs(p.want)) {
+			t.Errorf("%s: %s(%g) = %.15g, want %.15g", p.name, fn, p.x, got, p.want)
+		}
+	}
+}
+
+// Значення стандартного нормального розподілу — з таблиць функції Лапласа
+// та квантилів (Abramowitz & Stegun, табл. 26.1, 26.5).
+func TestNormal(t *testing.T) {
+	std := Normal{Mu: 0, Sigma: 1}
+	check(t, "PDF", std.PDF, []point{
+		{"N(0,1)", 0, 0.3989422804014327},
+		{"N(0,1)", 1, 0.24197072451914337},
+		{"N(0,1)", -2, 0.05399096651318806},
+	})
+	check(t, "CDF", std.CDF, []point{
+		{"N(0,1)", 0, 0.5},
+		{"N(0,1)", 1, 0.8413447460685429},
+		{"N(0,1)", 1.96, 0.9750021048517795},
+		{"N(0,1)", -3, 0.0013498980316300946},
+	})
+	check(t, "Quantile", std.Quantile, []point{
+		{"N(0,1)", 0.5, 0},
+		{"N(0,1)", 0.95, 1.6448536269514722},
+		{"N(0,1)", 0.975, 1.959963984540054},
+		{"N(0,1)", 0.001, -3.090232306167813},
+	})
+
+	d := Normal{Mu: 10, Sigma: 2}
+	check(t, "CDF", d.CDF, []point{{"N(10,2)", 12, 0.8413447460685429}})
+	check(t, "Quantile", d.Quantile, []point{{"N(10,2)", 0.975, 10 + 2*1.959963984540054}})
+}
+
+func TestLogNormal(t *testing.T) {
+	d := LogNormal{Mu: 0, Sigma: 1}
+	check(t, "PDF", d.PDF, []point{
+		{"LN(0,1)", 1, 0.3989422804014327},
+		{"LN(0,1)", 2, 0.1568740192789811},
+		{"LN(0,1)", 0, 0},
+	})
+	check(t, "CDF", d.CDF, []point{
+		{"LN(0,1)", 1, 0.5},
+		{"LN(0,1)", math.E, 0.8413447460685429},
+		{"LN(0,1)", -1, 0},
+	})
+	check(t, "Quantile", d.Quantile, []point{
+		{"LN(0,1)", 0.5, 1},
+		{"LN(0,1)", 0.975, 7.099071384231335},
+	})
+	if got := d.Mean(); math.Abs(got-math.Sqrt(math.E)) > 1e-12 {
+		t.Errorf("Mean = %g", got)
+	}
+	if got := d.StdDev(); math.Abs(got-2.1611974158950877) > 1e-12 {
+		t.Errorf("StdDev = %g", got)
+	}
+}
@@ -0,0 +1,90 @@
+// Пакет stats містить розподіли ймовірностей (нормальний, логнормальний,
+// Вейбулла, бета) та чисельне інтегрування для оцінки ймовірності
+// потрапляння випадкової величини в довільний інтервал.
+package stats
+
+import "math"
+
+// Distribution — неперервний розподіл випадкової величини.
+type Distribution interface {
+	PDF(x float64) float64      // Щільність ймовірності
+	CDF(x float64) float64      // Функція розподілу P(X ≤ x)
+	Quantile(p float64) float64 // Обернена функція розподілу
+	Mean() float64
+	StdDev() float64
+}
+
+// Probability повертає ймовірність потрапляння величини в інтервал [a, b].
+func Probability(d Distribution, a, b float64) float64 {
+	if b <= a {
+		return 0
+	}
+	return d.CDF(b) - d.CDF(a)
+}
+
+// Integrate обчислює інтеграл f на [a, b] адаптивним методом Сімпсона з
+// абсолютною похибкою не більше tol.
+func Integrate(f func(float64) float64, a, b, tol float64) float64 {
+	if a == b {
+		return 0
+	}
+	fa, fb, m := f(a), f(b), (a+b)/2
+	fm := f(m)
+	return simpson(f, a, b, fa, fm, fb, (b-a)/6*(fa+4*fm+fb), tol, 50)
+}
+
+func simpson(f func(float64) float64, a, b, fa, fm, fb, whole, tol float64, depth int) float64 {
+	m := (a + b) / 2
+	lm, rm := (a+m)/2, (m+b)/2
+	flm, frm := f(lm), f(rm)
+	left := (m - a) / 6 * (fa + 4*flm + fm)
+	right := (b - m) / 6 * (fm + 4*frm + fb)
+	if depth <= 0 || math.Abs(left+right-whole) <= 15*tol {
+		return left + right + (left+right-whole)/15
+	}
+	return simpson(f, a, m, fa, flm, fm, left, tol/2, depth-1) +
+		simpson(f, m, b, fm, frm, fb, right, tol/2, depth-1)
+}
+
+// invert знаходить x з cdf(x) = p методом Ньютона за щільністю pdf на
+// [lo, hi]; крок, що виходить за межі інтервалу, замінюється бісекцією.
+// Якщо hi недостатньо, інтервал розширюється.
+func invert(cdf, pdf func(float64) float64, p, lo, hi float64) float64 {
+	for cdf(hi) < p && hi < math.MaxFloat64/4 {
+		hi = lo + 2*(hi-lo)
+	}
+	x := (lo + hi) / 2
+	for range 200 {
+		f := cdf(x) - p
+		if f == 0 {
+			return x
+		}
+		if f < 0 {
+			lo = x
+		} else {
+			hi = x
+		}
+		next := x - f/pdf(x)
+		if !(next > lo && next < hi) {
+			next = (lo + hi) / 2
+		}
+		if math.Abs(next-x) <= 1e-14*math.Abs(x) || next == lo || next == hi {
+			return next
+		}
+		x = next
+	}
+	return x
+}
+
+// quantileBounds обробляє крайні значення p, спільні для всіх розподілів.
+func quantileBounds(p, min, max float64) (float64, bool) {
+	switch {
+	case math.IsNaN(p) || p < 0 || p > 1:
+		return math.NaN(), true
+	case p == 0:
+		return min, true
+	case p == 1:
+		return max, true
+	}
+	return 0, false
+}
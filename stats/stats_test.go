@@ -0,0 +1,68 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestInvert(t *testing.T) {
+	kde, err := NewKDE([]float64{1, 2, 2.5, 3, 7, 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dists := map[string]Distribution{
+		"beta(2,8)":     Beta{Alpha: 2, Beta: 8, Min: 0, Max: 1},
+		"beta(12,12)":   Beta{Alpha: 12, Beta: 12, Min: 0, Max: 10},
+		"beta(0.5,0.5)": Beta{Alpha: 0.5, Beta: 0.5, Min: 0, Max: 1},
+		"kde":           kde,
+	}
+	for name, d := range dists {
+		for _, p := range []float64{1e-9, 0.001, 0.1, 0.5, 0.9, 0.999} {
+			q := d.Quantile(p)
+			if got := d.CDF(q); math.Abs(got-p) > 1e-9*math.Max(1, p) {
+				t.Errorf("%s: CDF(Quantile(%g)) = %g", name, p, got)
+			}
+		}
+	}
+}
+
+func TestInvertExpandsBracket(t *testing.T) {
+	// Верхня межа замала: корінь лежить за нею
+	d := Normal{Mu: 100, Sigma: 1}
+	got := invert(d.CDF, d.PDF, 0.5, 0, 1)
+	if math.Abs(got-100) > 1e-9 {
+		t.Errorf("invert = %g, want 100", got)
+	}
+}
+
+// Інтеграл щільності по смузі порівнюється з різницею значень функції
+// розподілу, а інтеграли елементарних функцій — з відомими значеннями.
+func TestIntegrate(t *testing.T) {
+	std := Normal{Mu: 0, Sigma: 1}
+	b25 := Beta{Alpha: 2, Beta: 5, Min: 0, Max: 1}
+	arcsine := Beta{Alpha: 0.5, Beta: 0.5, Min: 0, Max: 1}
+	weibull := Weibull{K: 2, Lambda: 1}
+	tests := []struct {
+		name string
+		f    func(float64) float64
+		a, b float64
+		want float64
+	}{
+		{"N(0,1) ±1.96", std.PDF, -1.96, 1.96, 0.9500042097035591},
+		{"N(0,1) [-1, 1]", std.PDF, -1, 1, 0.6826894921370859},
+		{"N(5,1) ±0.25", Normal{Mu: 5, Sigma: 1}.PDF, 4.75, 5.25, Probability(Normal{Mu: 5, Sigma: 1}, 4.75, 5.25)},
+		{"B(2,5) [0, 0.2]", b25.PDF, 0, 0.2, 0.34464},
+		{"B(2,5) [0.2, 0.5]", b25.PDF, 0.2, 0.5, 0.890625 - 0.34464},
+		{"B(1/2,1/2) [1/4, 3/4]", arcsine.PDF, 0.25, 0.75, 1.0 / 3},
+		{"W(2,1) [0, 1]", weibull.PDF, 0, 1, 1 - 1/math.E},
+		{"x²", func(x float64) float64 { return x * x }, 0, 3, 9},
+		{"sin", math.Sin, 0, math.Pi, 2},
+		{"empty", math.Sin, 1, 1, 0},
+		{"reversed", func(x float64) float64 { return x * x }, 3, 0, -9},
+	}
+	for _, tt := range tests {
+		if got := Integrate(tt.f, tt.a, tt.b, 1e-10); math.Abs(got-tt.want) > 1e-8 {
+			t.Errorf("%s: Integrate = %.12g, want %.12g", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package stats
+
+import "math"
+
+// Weibull — розподіл Вейбулла з параметром форми K та масштабу Lambda.
+type Weibull struct {
+	K, Lambda float64
+}
+
+func (w Weibull) PDF(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	z := x / w.Lambda
+	return w.K / w.Lambda * math.Pow(z, w.K-1) * math.Exp(-math.Pow(z, w.K))
+}
+
+func (w Weibull) CDF(x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	return -math.Expm1(-math.Pow(x/w.Lambda, w.K))
+}
+
+func (w Weibull) Quantile(p float64) float64 {
+	if q, ok := quantileBounds(p, 0, math.Inf(1)); ok {
+		return q
+	}
+	return w.Lambda * math.Pow(-math.Log1p(-p), 1/w.K)
+}
+
+func (w Weibull) Mean() float64 { return w.Lambda * math.Gamma(1+1/w.K) }
+
+func (w Weibull) StdDev() float64 {
+	g1 := math.Gamma(1 + 1/w.K)
+	return w.Lambda * math.Sqrt(math.Gamma(1+2/w.K)-g1*g1)
+}
+
+// weibullCV — коефіцієнт варіації розподілу Вейбулла з формою k.
+func weibullCV(k float64) float64 {
+	g1 := math.Gamma(1 + 1/k)
+	return math.Sqrt(math.Gamma(1+2/k)/(g1*g1) - 1)
+}
@@ -0,0 +1,36 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+// Розподіл Вейбулла з k = 2 — розподіл Релея, з k = 1 — експоненційний;
+// для обох значення відомі в замкненому вигляді.
+func TestWeibull(t *testing.T) {
+	rayleigh := Weibull{K: 2, Lambda: 1}
+	check(t, "PDF", rayleigh.PDF, []point{
+		{"W(2,1)", 1, 2 / math.E},
+		{"W(2,1)", 0, 0},
+		{"W(2,1)", -1, 0},
+	})
+	check(t, "CDF", rayleigh.CDF, []point{
+		{"W(2,1)", 1, 1 - 1/math.E},
+		{"W(2,1)", 2, 1 - math.Exp(-4)},
+	})
+	check(t, "Quantile", rayleigh.Quantile, []point{
+		{"W(2,1)", 0.5, math.Sqrt(math.Ln2)},
+		{"W(2,1)", 0, 0},
+	})
+	if got := rayleigh.Mean(); math.Abs(got-math.Sqrt(math.Pi)/2) > 1e-12 {
+		t.Errorf("Mean = %g", got)
+	}
+	if got := rayleigh.StdDev(); math.Abs(got-math.Sqrt(1-math.Pi/4)) > 1e-12 {
+		t.Errorf("StdDev = %g", got)
+	}
+
+	exp := Weibull{K: 1, Lambda: 2}
+	check(t, "PDF", exp.PDF, []point{{"W(1,2)", 0, 0.5}, {"W(1,2)", 2, 0.5 / math.E}})
+	check(t, "CDF", exp.CDF, []point{{"W(1,2)", 2, 1 - 1/math.E}})
+	check(t, "Quantile", exp.Quantile, []point{{"W(1,2)", 0.5, 2 * math.Ln2}})
+}
@@ -0,0 +1,247 @@
+// Пакет tax розраховує екологічний податок за викиди забруднюючих речовин
+// в атмосферне повітря стаціонарними джерелами.
+package tax
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+
+	"Go_tutor/emissions"
+	"Go_tutor/fsutil"
+	"Go_tutor/validate"
+)
+
+// ErrNoRates повертається, якщо для року немає ставок податку.
+var ErrNoRates = errors.New("no tax rates for the year")
+
+// Schedule — ставки податку (грн за тонну) за речовинами, що діють з року
+// Year до року наступних ставок.
+type Schedule struct {
+	Year  int                `json:"year"`
+	Rates map[string]float64 `json:"rates"`
+}
+
+// Item — податок за одну речовину.
+type Item struct {
+	Pollutant string  `json:"pollutant"`
+	Emission  float64 `json:"emission"` // Валовий викид (т)
+	Rate      float64 `json:"rate"`     // Ставка (грн/т)
+	Amount    float64 `json:"amount"`   // Сума податку (грн)
+}
+
+// Tax — податок за викиди.
+type Tax struct {
+	Year     int     `json:"year"`      // Рік, для якого розраховано податок
+	RateYear int     `json:"rate_year"` // Рік, з якого діють застосовані ставки
+	Items    []Item  `json:"items"`
+	Total    float64 `json:"total"` // Загальна сума (грн)
+}
+
+// Validate перевіряє ставки перед збереженням.
+func (s Schedule) Validate() error {
+	errs := validate.Errors{}
+	errs.Range("year", float64(s.Year), 1990, 2100)
+	for p, rate := range s.Rates {
+		switch p {
+		case emissions.PollutantParticulates, emissions.PollutantSO2, emissions.PollutantNOx, emissions.PollutantCO2:
+			errs.NonNegative("rates."+p, rate)
+		default:
+			errs.Add("rates."+p, "unknown pollutant")
+		}
+	}
+	return errs.Err()
+}
+
+// Tax множить валові викиди t на ставки.
+func (s Schedule) Tax(year int, t emissions.Totals) Tax {
+	res := Tax{Year: year, RateYear: s.Year, Items: []Item{}}
+	for _, p := range emissions.Pollutants() {
+		rate, ok := s.Rates[p]
+		if !ok {
+			continue
+		}
+		item := Item{Pollutant: p, Emission: t.Pollutant(p).Total, Rate: rate}
+		item.Amount = item.Emission * item.Rate
+		res.Items = append(res.Items, item)
+		res.Total += item.Amount
+	}
+	return res
+}
+
+// Ставки за ст. 243 Податкового кодексу України
+var builtinSchedules = []Schedule{
+	{Year: 2021, Rates: map[string]float64{
+		emissions.PollutantParticulates: 96.42,
+		emissions.PollutantSO2:          2574.43,
+		emissions.PollutantNOx:          2574.43,
+		emissions.PollutantCO2:          10,
+	}},
+	{Year: 2022, Rates: map[string]float64{
+		emissions.PollutantParticulates: 96.42,
+		emissions.PollutantSO2:          2574.43,
+		emissions.PollutantNOx:          2574.43,
+		emissions.PollutantCO2:          30,
+	}},
+}
+
+// Default — ставки, з якими працюють калькулятор, журнал та API.
+var Default = NewTable(builtinSchedules)
+
+// Table — ставки податку за роками, безпечні для одночасного
+// використання. Після Open зміни зберігаються у файл.
+type Table struct {
+	mu        sync.RWMutex
+	schedules []Schedule
+	path      string
+}
+
+// NewTable створює таблицю ставок у пам'яті з копії schedules.
+func NewTable(schedules []Schedule) *Table {
+	t := &Table{schedules: append([]Schedule(nil), schedules...)}
+	sortSchedules(t.schedules)
+	return t
+}
+
+// List повертає ставки за зростанням року.
+func (t *Table) List() []Schedule {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return append([]Schedule(nil), t.schedules...)
+}
+
+// Get повертає ставки, задані саме для року year.
+func (t *Table) Get(year int) (Schedule, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	i := t.index(year)
+	if i < 0 {
+		return Schedule{}, false
+	}
+	return t.schedules[i], true
+}
+
+// For повертає ставки, чинні в році year: задані для цього року або
+// найближчого попереднього.
+func (t *Table) For(year int) (Schedule, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	for i := len(t.schedules) - 1; i >= 0; i-- {
+		if t.schedules[i].Year <= year {
+			return t.schedules[i], true
+		}
+	}
+	return Schedule{}, false
+}
+
+// Calculate розраховує податок за валові викиди totals за рік year.
+func (t *Table) Calculate(year int, totals emissions.Totals) (Tax, error) {
+	s, ok := t.For(year)
+	if !ok {
+		return Tax{}, ErrNoRates
+	}
+	return s.Tax(year, totals), nil
+}
+
+// Put додає або замінює ставки року. Повертає true, якщо ставки додано.
+func (t *Table) Put(s Schedule) (bool, error) {
+	if err := s.Validate(); err != nil {
+		return false, err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	schedules := append([]Schedule(nil), t.schedules...)
+	i := t.index(s.Year)
+	if i < 0 {
+		schedules = append(schedules, s)
+		sortSchedules(schedules)
+	} else {
+		schedules[i] = s
+	}
+	if err := t.save(schedules); err != nil {
+		return false, err
+	}
+	t.schedules = schedules
+	return i < 0, nil
+}
+
+// Delete видаляє ставки року.
+func (t *Table) Delete(year int) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	i := t.index(year)
+	if i < 0 {
+		return ErrNoRates
+	}
+	schedules := append(append([]Schedule(nil), t.schedules[:i]...), t.schedules[i+1:]...)
+	if err := t.save(schedules); err != nil {
+		return err
+	}
+	t.schedules = schedules
+	return nil
+}
+
+// Open прив'язує таблицю до JSON-файлу. Наявний файл завантажується, а
+// якщо його немає, у нього записуються поточні ставки.
+func (t *Table) Open(path string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.path = path
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return t.save(t.schedules)
+	}
+	if err != nil {
+		return err
+	}
+
+	var schedules []Schedule
+	if err := json.Unmarshal(data, &schedules); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	seen := make(map[int]bool, len(schedules))
+	for _, s := range schedules {
+		if err := s.Validate(); err != nil {
+			return fmt.Errorf("%s: year %d: %w", path, s.Year, err)
+		}
+		if seen[s.Year] {
+			return fmt.Errorf("%s: duplicate year %d", path, s.Year)
+		}
+		seen[s.Year] = true
+	}
+	sortSchedules(schedules)
+	t.schedules = schedules
+	return nil
+}
+
+func (t *Table) index(year int) int {
+	for i, s := range t.schedules {
+		if s.Year == year {
+			return i
+		}
+	}
+	return -1
+}
+
+func sortSchedules(schedules []Schedule) {
+	sort.Slice(schedules, func(i, j int) bool { return schedules[i].Year < schedules[j].Year })
+}
+
+// save атомарно записує ставки у файл, якщо його задано.
+func (t *Table) save(schedules []Schedule) error {
+	if t.path == "" {
+		return nil
+	}
+	if schedules == nil {
+		schedules = []Schedule{}
+	}
+	return fsutil.WriteFileAtomic(t.path, func(w io.Writer) error {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(schedules)
+	})
+}
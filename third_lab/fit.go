@@ -0,0 +1,144 @@
+package thirdlab
+
+import (
+	"html/template"
+	"io"
+	"net/http"
+
+	"Go_tutor/batch"
+	"Go_tutor/i18n"
+	"Go_tutor/number"
+	"Go_tutor/solar"
+	"Go_tutor/validate"
+)
+
+type fitPage struct {
+	i18n.Localizer
+	Form   *validate.Form
+	Num    number.Formatter
+	Result *solar.FitResult
+}
+
+var fitSpecs = number.Specs{
+	"mw":           {Precision: 3, Unit: "unit.mw"},
+	"share":        {Precision: 2, Unit: "unit.percent"},
+	"energy_share": {Precision: 2, Unit: "unit.percent"},
+	"profit":       {Precision: 2, Unit: "unit.thousand_uah"},
+	"penalty":      {Precision: 2, Unit: "unit.thousand_uah"},
+	"gain":         {Precision: 2, Unit: "unit.thousand_uah"},
+}
+
+// FitHandler оцінює розподіл похибки прогнозу за завантаженою погодинною
+// історією прогнозу та генерації й рахує за ним прибуток і штраф.
+func FitHandler(w http.ResponseWriter, r *http.Request) {
+	loc := i18n.FromRequest(w, r)
+	data := fitPage{Localizer: loc, Num: loc.Formatter(r, fitSpecs)}
+	if r.Method != http.MethodPost {
+		fitTmpl.Execute(w, data)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, batch.MaxSize)
+	r.ParseMultipartForm(batch.MaxSize)
+	form := loc.Form(r)
+	in := solar.FitInput{
+		NewSigma: form.OptionalFloat("new_sigma"),
+		Band:     form.OptionalFloat("band"),
+		Tariff:   form.OptionalFloat("tariff"),
+	}
+	data.Form = form
+
+	f, _, err := r.FormFile("file")
+	if err != nil {
+		form.Errors.Add("file", "choose a file to upload")
+		fitTmpl.Execute(w, data)
+		return
+	}
+	defer f.Close()
+	content, err := io.ReadAll(f)
+	if err == nil {
+		in.Samples, err = solar.ReadSamples(content)
+	}
+	if err != nil {
+		form.Fail(err)
+	}
+
+	if form.Valid() {
+		if result, err := solar.FitErrors(in); err != nil {
+			form.Fail(err)
+		} else {
+			data.Result = &result
+		}
+	}
+	fitTmpl.Execute(w, data)
+}
+
+var fitTmpl = template.Must(template.New("fit").Parse(`
+<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+	<meta charset="UTF-8">
+	<title>{{.T "fit.title"}}</title>
+	<style>
+		body { font-family: Arial, sans-serif; text-align: center; background-color: #f4f4f4; padding: 50px; }
+		.container { background: white; padding: 20px; border-radius: 10px; box-shadow: 0px 0px 10px rgba(0, 0, 0, 0.1); display: inline-block; }
+		input, button { margin: 10px; padding: 10px; font-size: 16px; }
+		button { background-color: #ff9800; color: white; border: none; cursor: pointer; }
+		button:hover { background-color: #e68900; }
+		table { border-collapse: collapse; margin: 10px auto; }
+		th, td { border: 1px solid #ccc; padding: 6px 10px; }
+		.error { color: #dc3545; font-size: 0.9em; }
+	</style>
+</head>
+<body>
+	{{.Switcher}}
+	<div class="container">
+	<h2>{{.T "fit.heading"}}</h2>
+	<p>{{.T "fit.hint"}}</p>
+	<form method="post" action="fit" enctype="multipart/form-data">
+		<input type="file" name="file" accept=".csv,.xlsx">
+		{{with .Form.Error "file"}}<span class="error">{{.}}</span>{{end}}<br>
+		<label>{{.T "solar.band"}}:</label>
+		<input type="text" name="band" placeholder="5" value="{{.Form.Value "band"}}">
+		{{with .Form.Error "band"}}<span class="error">{{.}}</span>{{end}}<br>
+		<label>{{.T "solar.new_sigma"}}:</label>
+		<input type="text" name="new_sigma" placeholder="0,25·σ" value="{{.Form.Value "new_sigma"}}">
+		{{with .Form.Error "new_sigma"}}<span class="error">{{.}}</span>{{end}}<br>
+		<label>{{.T "solar.tariff"}}:</label>
+		<input type="text" name="tariff" placeholder="7" value="{{.Form.Value "tariff"}}">
+		{{with .Form.Error "tariff"}}<span class="error">{{.}}</span>{{end}}<br>
+		{{with .Form.Error "samples"}}<p class="error">{{.}}</p>{{end}}
+		{{with .Form.Error ""}}<p class="error">{{.}}</p>{{end}}
+		<button type="submit">{{.T "common.calculate"}}</button>
+	</form>
+	{{with .Result}}
+	{{with .Fit}}
+	<table>
+		<tr><td>{{$.T "fit.hours"}}</td><td>{{.Hours}}</td></tr>
+		<tr><td>{{$.T "fit.pc"}}</td><td>{{$.Num.Format "mw" .Pc}}</td></tr>
+		<tr><td>{{$.T "fit.bias"}}</td><td>{{$.Num.Format "mw" .Bias}}</td></tr>
+		<tr><td>{{$.T "fit.sigma"}}</td><td>{{$.Num.Format "mw" .Sigma}}</td></tr>
+		<tr><td>{{$.T "fit.bandwidth"}}</td><td>{{$.Num.Format "mw" .Bandwidth}}</td></tr>
+		<tr><td>{{$.T "fit.hours_within"}}</td><td>{{$.Num.Format "share" .HoursWithin}}</td></tr>
+		<tr><td>{{$.T "fit.energy_within"}}</td><td>{{$.Num.Format "share" .EnergyWithin}}</td></tr>
+		<tr><td>{{$.T "fit.normal_share"}}</td><td>{{$.Num.Format "share" .NormalShare}}</td></tr>
+		<tr><td>{{$.T "fit.kde_share"}}</td><td>{{$.Num.Format "share" .KDEShare}}</td></tr>
+	</table>
+	{{end}}
+	<table>
+		<tr><th></th><th>{{$.T "distribution.normal"}}</th><th>{{$.T "distribution.empirical"}}</th></tr>
+		<tr><th colspan="3">{{$.T "solar.current"}}</th></tr>
+		<tr><td>{{$.T "solar.energy_share"}}</td><td>{{$.Num.Format "energy_share" .Normal.Current.EnergyShare}}</td><td>{{$.Num.Format "energy_share" .Empirical.Current.EnergyShare}}</td></tr>
+		<tr><td>{{$.T "solar.profit"}}</td><td>{{$.Num.Format "profit" .Normal.Current.Profit}}</td><td>{{$.Num.Format "profit" .Empirical.Current.Profit}}</td></tr>
+		<tr><td>{{$.T "solar.penalty"}}</td><td>{{$.Num.Format "penalty" .Normal.Current.Penalty}}</td><td>{{$.Num.Format "penalty" .Empirical.Current.Penalty}}</td></tr>
+		<tr><th colspan="3">{{$.T "solar.improved"}}</th></tr>
+		<tr><td>{{$.T "solar.energy_share"}}</td><td>{{$.Num.Format "energy_share" .Normal.Improved.EnergyShare}}</td><td>{{$.Num.Format "energy_share" .Empirical.Improved.EnergyShare}}</td></tr>
+		<tr><td>{{$.T "solar.profit"}}</td><td>{{$.Num.Format "profit" .Normal.Improved.Profit}}</td><td>{{$.Num.Format "profit" .Empirical.Improved.Profit}}</td></tr>
+		<tr><td>{{$.T "solar.penalty"}}</td><td>{{$.Num.Format "penalty" .Normal.Improved.Penalty}}</td><td>{{$.Num.Format "penalty" .Empirical.Improved.Penalty}}</td></tr>
+	</table>
+	{{end}}
+	<p><a href="./">{{.T "common.back"}}</a></p>
+	</div>
+</body>
+</html>
+`))
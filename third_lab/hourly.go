@@ -0,0 +1,188 @@
+package thirdlab
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"Go_tutor/i18n"
+	"Go_tutor/number"
+	"Go_tutor/solar"
+	"Go_tutor/stats"
+	"Go_tutor/validate"
+)
+
+type hourlyPage struct {
+	i18n.Localizer
+	Form          *validate.Form
+	Num           number.Formatter
+	Result        *solar.HourlyResult
+	FlatWarning   string // Чому немає розрахунку за середньодобовою потужністю
+	Hours         []int
+	Distributions []string
+}
+
+var hourlySpecs = number.Specs{
+	"mw":           {Precision: 3, Unit: "unit.mw"},
+	"tariff":       {Precision: 2},
+	"energy_share": {Precision: 2, Unit: "unit.percent"},
+	"profit":       {Precision: 3, Unit: "unit.thousand_uah"},
+	"penalty":      {Precision: 3, Unit: "unit.thousand_uah"},
+	"gain":         {Precision: 2, Unit: "unit.thousand_uah"},
+}
+
+// HourlyHandler рахує прибуток і штраф сонячної електростанції окремо для
+// кожної години доби.
+func HourlyHandler(w http.ResponseWriter, r *http.Request) {
+	loc := i18n.FromRequest(w, r)
+	data := hourlyPage{Localizer: loc, Num: loc.Formatter(r, hourlySpecs), Distributions: stats.Kinds()}
+	for h := range solar.Hours {
+		data.Hours = append(data.Hours, h)
+	}
+	if r.Method != http.MethodPost {
+		hourlyTmpl.Execute(w, data)
+		return
+	}
+
+	form := loc.Form(r)
+	in := solar.HourlyInput{
+		Profile:      hourlyValues(form, "profile"),
+		Sigma:        hourlyValues(form, "sigma"),
+		Tariff:       hourlyValues(form, "tariff"),
+		Band:         form.OptionalFloat("band"),
+		Distribution: form.Value("distribution"),
+	}
+	// Без профілю використовується крива ясного неба, без σ — похибка
+	if in.Profile == nil {
+		in.Pc = form.Float("pc")
+	}
+	if in.Sigma == nil {
+		in.Delta = form.Float("delta")
+	}
+	data.Form = form
+	if form.Valid() {
+		if result, err := solar.CalculateHourly(in); err != nil {
+			form.Fail(err)
+		} else {
+			data.Result = &result
+			if len(result.FlatWarning) > 0 {
+				data.FlatWarning = loc.Error(result.FlatWarning)
+			}
+		}
+	}
+	hourlyTmpl.Execute(w, data)
+}
+
+// hourlyValues читає поля name.0 … name.23. Якщо всі порожні, повертає
+// nil; якщо заповнено частину, решта позначається як обов'язкова.
+func hourlyValues(form *validate.Form, name string) []float64 {
+	empty := true
+	for h := range solar.Hours {
+		if strings.TrimSpace(form.Value(fmt.Sprintf("%s.%d", name, h))) != "" {
+			empty = false
+		}
+	}
+	if empty {
+		return nil
+	}
+	values := make([]float64, solar.Hours)
+	for h := range values {
+		values[h] = form.Float(fmt.Sprintf("%s.%d", name, h))
+	}
+	return values
+}
+
+var hourlyTmpl = template.Must(template.New("hourly").Parse(`
+<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+	<meta charset="UTF-8">
+	<title>{{.T "hourly.title"}}</title>
+	<style>
+		body { font-family: Arial, sans-serif; text-align: center; background-color: #f4f4f4; padding: 50px; }
+		.container { background: white; padding: 20px; border-radius: 10px; box-shadow: 0px 0px 10px rgba(0, 0, 0, 0.1); display: inline-block; }
+		input, button { margin: 4px; padding: 6px; font-size: 14px; }
+		td input { width: 70px; }
+		button { background-color: #ff9800; color: white; border: none; cursor: pointer; padding: 10px; font-size: 16px; }
+		button:hover { background-color: #e68900; }
+		table { border-collapse: collapse; margin: 10px auto; }
+		th, td { border: 1px solid #ccc; padding: 4px 8px; }
+		.error { color: #dc3545; font-size: 0.9em; }
+	</style>
+</head>
+<body>
+	{{.Switcher}}
+	<div class="container">
+	<h2>{{.T "hourly.heading"}}</h2>
+	<form method="post" action="hourly">
+		<p>{{.T "hourly.hint"}}</p>
+		<label>{{.T "solar.pc"}}:</label>
+		<input type="text" name="pc" value="{{.Form.Value "pc"}}">{{with .Form.Error "pc"}}<span class="error">{{.}}</span>{{end}}<br>
+		<label>{{.T "solar.delta"}}:</label>
+		<input type="text" name="delta" value="{{.Form.Value "delta"}}">{{with .Form.Error "delta"}}<span class="error">{{.}}</span>{{end}}<br>
+		<label>{{.T "solar.band"}}:</label>
+		<input type="text" name="band" placeholder="5" value="{{.Form.Value "band"}}">{{with .Form.Error "band"}}<span class="error">{{.}}</span>{{end}}<br>
+		<label>{{.T "solar.distribution"}}:</label>
+		<select name="distribution">
+			{{range .Distributions}}<option value="{{.}}"{{if eq . ($.Form.Value "distribution")}} selected{{end}}>{{$.T (print "distribution." .)}}</option>{{end}}
+		</select>{{with .Form.Error "distribution"}}<span class="error">{{.}}</span>{{end}}
+		<table>
+			<tr><th>{{.T "hourly.hour"}}</th><th>{{.T "hourly.forecast"}}</th><th>{{.T "solar.sigma"}}</th><th>{{.T "solar.tariff"}}</th></tr>
+			{{range .Hours}}{{$p := print "profile." .}}{{$s := print "sigma." .}}{{$t := print "tariff." .}}
+			<tr>
+				<td>{{.}}:00</td>
+				<td><input type="text" name="{{$p}}" value="{{$.Form.Value $p}}">{{with $.Form.Error $p}}<span class="error">{{.}}</span>{{end}}</td>
+				<td><input type="text" name="{{$s}}" value="{{$.Form.Value $s}}">{{with $.Form.Error $s}}<span class="error">{{.}}</span>{{end}}</td>
+				<td><input type="text" name="{{$t}}" placeholder="7" value="{{$.Form.Value $t}}">{{with $.Form.Error $t}}<span class="error">{{.}}</span>{{end}}</td>
+			</tr>
+			{{end}}
+		</table>
+		{{with .Form.Error "profile"}}<p class="error">{{.}}</p>{{end}}
+		{{with .Form.Error ""}}<p class="error">{{.}}</p>{{end}}
+		<button type="submit">{{.T "common.calculate"}}</button>
+	</form>
+	{{with .Result}}
+	<h3>{{$.T "common.results"}}</h3>
+	<table>
+		<tr>
+			<th rowspan="2">{{$.T "hourly.hour"}}</th><th rowspan="2">{{$.T "hourly.forecast"}}</th><th rowspan="2">σ</th>
+			<th colspan="3">{{$.T "solar.current"}}</th><th colspan="3">{{$.T "solar.improved"}}</th>
+		</tr>
+		<tr>
+			<th>{{$.T "solar.energy_share"}}</th><th>{{$.T "solar.profit"}}</th><th>{{$.T "solar.penalty"}}</th>
+			<th>{{$.T "solar.energy_share"}}</th><th>{{$.T "solar.profit"}}</th><th>{{$.T "solar.penalty"}}</th>
+		</tr>
+		{{range .Hours}}{{if .Forecast}}
+		<tr>
+			<td>{{.Hour}}:00</td><td>{{$.Num.Format "mw" .Forecast}}</td><td>{{$.Num.Format "mw" .Sigma}}</td>
+			<td>{{$.Num.Format "energy_share" .Current.EnergyShare}}</td><td>{{$.Num.Format "profit" .Current.Profit}}</td><td>{{$.Num.Format "penalty" .Current.Penalty}}</td>
+			<td>{{$.Num.Format "energy_share" .Improved.EnergyShare}}</td><td>{{$.Num.Format "profit" .Improved.Profit}}</td><td>{{$.Num.Format "penalty" .Improved.Penalty}}</td>
+		</tr>
+		{{end}}{{end}}
+		<tr>
+			<th colspan="3">{{$.T "hourly.day"}}</th>
+			<th>{{$.Num.Format "energy_share" .Current.EnergyShare}}</th><th>{{$.Num.Format "profit" .Current.Profit}}</th><th>{{$.Num.Format "penalty" .Current.Penalty}}</th>
+			<th>{{$.Num.Format "energy_share" .Improved.EnergyShare}}</th><th>{{$.Num.Format "profit" .Improved.Profit}}</th><th>{{$.Num.Format "penalty" .Improved.Penalty}}</th>
+		</tr>
+		{{with .Flat}}
+		<tr>
+			<th colspan="3">{{$.T "hourly.flat"}}</th>
+			<td>{{$.Num.Format "energy_share" .Current.EnergyShare}}</td><td>{{$.Num.Format "profit" .Current.Profit}}</td><td>{{$.Num.Format "penalty" .Current.Penalty}}</td>
+			<td>{{$.Num.Format "energy_share" .Improved.EnergyShare}}</td><td>{{$.Num.Format "profit" .Improved.Profit}}</td><td>{{$.Num.Format "penalty" .Improved.Penalty}}</td>
+		</tr>
+		{{end}}
+		{{with $.FlatWarning}}
+		<tr>
+			<th colspan="3">{{$.T "hourly.flat"}}</th>
+			<td colspan="6">{{$.T "hourly.flat_unavailable" .}}</td>
+		</tr>
+		{{end}}
+	</table>
+	<p><b>{{$.T "solar.gain" ($.Num.Format "gain" .Gain)}}</b></p>
+	{{end}}
+	<p><a href="./">{{.T "common.back"}}</a></p>
+	</div>
+</body>
+</html>
+`))
@@ -1,99 +1,239 @@
-package main
+package thirdlab
 
 import (
-	"fmt"
-	"math"
+	"html/template"
 	"net/http"
 	"strconv"
+	"strings"
+
+	"Go_tutor/i18n"
+	"Go_tutor/number"
+	"Go_tutor/solar"
+	"Go_tutor/stats"
+	"Go_tutor/validate"
 )
 
-func main() {
-	http.HandleFunc("/", homeHandler)
-	http.ListenAndServe(":8080", nil)
+type pageData struct {
+	i18n.Localizer
+	Form          *validate.Form
+	Num           number.Formatter
+	Result        *solar.Result
+	Distributions []string
+	Simulation    *solar.Simulation
+	Histograms    []histogram
+}
+
+// histogram — розподіл доходу, підготовлений для стовпчикової діаграми.
+type histogram struct {
+	Title string
+	solar.Revenue
+	Bars     []bar
+	Min, Max float64 // Межі осі доходу
+}
+
+// bar — інтервал гістограми з висотою стовпчика у відсотках від
+// найвищого.
+type bar struct {
+	stats.Bin
+	Height float64
+}
+
+func newHistogram(title string, r solar.Revenue) histogram {
+	h := histogram{Title: title, Revenue: r}
+	top := 0
+	for _, b := range r.Histogram {
+		top = max(top, b.Count)
+	}
+	if n := len(r.Histogram); n > 0 {
+		h.Min, h.Max = r.Histogram[0].From, r.Histogram[n-1].To
+	}
+	for _, b := range r.Histogram {
+		h.Bars = append(h.Bars, bar{b, float64(b.Count) / float64(top) * 100})
+	}
+	return h
+}
+
+var resultSpecs = number.Specs{
+	"pc":           {Precision: 2, Unit: "unit.mw"},
+	"delta":        {Precision: 2, Unit: "unit.percent"},
+	"energy_share": {Precision: 2, Unit: "unit.percent"},
+	"profit":       {Precision: 2, Unit: "unit.thousand_uah"},
+	"penalty":      {Precision: 2, Unit: "unit.thousand_uah"},
+	"gain":         {Precision: 2, Unit: "unit.thousand_uah"},
+	"sigma":        {Precision: 3, Unit: "unit.mw"},
+	"band":         {Precision: 2, Unit: "unit.percent"},
+	"tolerance":    {Precision: 3, Unit: "unit.mw"},
+	"tariff":       {Precision: 2, Unit: "unit.uah_per_kwh"},
+	"revenue":      {Precision: 2, Unit: "unit.thousand_uah"},
+	"loss":         {Precision: 2, Unit: "unit.percent"},
 }
 
-func homeHandler(w http.ResponseWriter, r *http.Request) {
+func HomeHandler(w http.ResponseWriter, r *http.Request) {
+	loc := i18n.FromRequest(w, r)
 	if r.Method == http.MethodGet {
-		fmt.Fprintf(w, `
-		<html>
+		tmpl.Execute(w, pageData{Localizer: loc, Distributions: stats.Kinds()})
+	} else if r.Method == http.MethodPost {
+		form := loc.Form(r)
+		// Порожні необов'язкові поля беруться за замовчуванням, а без σ
+		// воно визначається з похибки прогнозу
+		input := solar.Input{
+			Pc:       form.Float("pc"),
+			Sigma:    form.OptionalFloat("sigma"),
+			NewSigma: form.OptionalFloat("new_sigma"),
+			Band:     form.OptionalFloat("band"),
+			Tariff:   form.OptionalFloat("tariff"),
+
+			Distribution: form.Value("distribution"),
+		}
+		if delta := form.OptionalFloat("delta"); delta != nil {
+			input.Delta = *delta
+		}
+
+		// Задана кількість діб вмикає моделювання Монте-Карло
+		simulate := strings.TrimSpace(form.Value("days")) != ""
+		days := 0
+		if simulate {
+			days = form.Int("days")
+		}
+		var seed *int64
+		if s := strings.TrimSpace(form.Value("seed")); s != "" {
+			if v, err := strconv.ParseInt(s, 10, 64); err != nil {
+				form.Errors.Add("seed", "must be a whole number")
+			} else {
+				seed = &v
+			}
+		}
+
+		data := pageData{Localizer: loc, Form: form, Num: loc.Formatter(r, resultSpecs), Distributions: stats.Kinds()}
+		if form.Valid() && simulate {
+			sim, err := solar.Simulate(solar.SimulationInput{Input: input, Days: days, Seed: seed})
+			if err != nil {
+				form.Fail(err)
+			} else {
+				data.Simulation = &sim
+				data.Result = &sim.Expected
+				data.Histograms = []histogram{
+					newHistogram("solar.current", sim.Current),
+					newHistogram("solar.improved", sim.Improved),
+					newHistogram("monte_carlo.difference", sim.Difference),
+				}
+			}
+		} else if form.Valid() {
+			if result, err := solar.Calculate(input); err != nil {
+				form.Fail(err)
+			} else {
+				data.Result = &result
+			}
+		}
+		tmpl.Execute(w, data)
+	}
+}
+
+var tmpl = template.Must(template.New("solar").Parse(`
+		<html lang="{{.Lang}}">
 		<head>
-		<title>Розрахунок прибутку</title>
+		<meta charset="UTF-8">
+		<title>{{.T "solar.title"}}</title>
 		<style>
 			body { font-family: Arial, sans-serif; text-align: center; background-color: #f4f4f4; padding: 50px; }
 			.container { background: white; padding: 20px; border-radius: 10px; box-shadow: 0px 0px 10px rgba(0, 0, 0, 0.1); display: inline-block; }
 			input, button { margin: 10px; padding: 10px; font-size: 16px; }
 			button { background-color: #ff9800; color: white; border: none; cursor: pointer; }
 			button:hover { background-color: #e68900; }
+			.error { color: #dc3545; font-size: 0.9em; }
+			.results { text-align: left; }
+			table { border-collapse: collapse; margin: 10px 0; }
+			th, td { border: 1px solid #ccc; padding: 4px 8px; }
+			.chart { display: flex; align-items: flex-end; height: 120px; gap: 2px; border-bottom: 1px solid #999; }
+			.chart div { flex: 1; background-color: #ff9800; min-height: 1px; }
+			.axis { display: flex; justify-content: space-between; font-size: 0.8em; }
 		</style>
 		</head>
 		<body>
+		{{.Switcher}}
 		<div class="container">
-		<h2>Розрахунок прибутку від сонячних електростанцій</h2>
-		<form action="/calculate" method="post">
-			<label>Середньодобова потужність (Pc) у МВт:</label>
-			<input type="number" step="any" name="pc" required><br>
-			<label>Похибка прогнозу (%):</label>
-			<input type="number" step="any" name="delta" required><br>
-			<button type="submit">Розрахувати</button>
+		<h2>{{.T "solar.heading"}}</h2>
+		<form action="calculate" method="post">
+			<label>{{.T "solar.pc"}}:</label>
+			<input type="text" name="pc" value="{{.Form.Value "pc"}}" required>
+			{{with .Form.Error "pc"}}<span class="error">{{.}}</span>{{end}}<br>
+			<label>{{.T "solar.delta"}}:</label>
+			<input type="text" name="delta" value="{{.Form.Value "delta"}}">
+			{{with .Form.Error "delta"}}<span class="error">{{.}}</span>{{end}}<br>
+			<label>{{.T "solar.sigma"}}:</label>
+			<input type="text" name="sigma" placeholder="Pc·δ/100" value="{{.Form.Value "sigma"}}">
+			{{with .Form.Error "sigma"}}<span class="error">{{.}}</span>{{end}}<br>
+			<label>{{.T "solar.new_sigma"}}:</label>
+			<input type="text" name="new_sigma" placeholder="0,25·σ" value="{{.Form.Value "new_sigma"}}">
+			{{with .Form.Error "new_sigma"}}<span class="error">{{.}}</span>{{end}}<br>
+			<label>{{.T "solar.band"}}:</label>
+			<input type="text" name="band" placeholder="5" value="{{.Form.Value "band"}}">
+			{{with .Form.Error "band"}}<span class="error">{{.}}</span>{{end}}<br>
+			<label>{{.T "solar.tariff"}}:</label>
+			<input type="text" name="tariff" placeholder="7" value="{{.Form.Value "tariff"}}">
+			{{with .Form.Error "tariff"}}<span class="error">{{.}}</span>{{end}}<br>
+			<label>{{.T "solar.distribution"}}:</label>
+			<select name="distribution">
+				{{range .Distributions}}<option value="{{.}}"{{if eq . ($.Form.Value "distribution")}} selected{{end}}>{{$.T (print "distribution." .)}}</option>{{end}}
+			</select>
+			{{with .Form.Error "distribution"}}<span class="error">{{.}}</span>{{end}}<br>
+			<label>{{.T "monte_carlo.days"}}:</label>
+			<input type="text" name="days" value="{{.Form.Value "days"}}">
+			{{with .Form.Error "days"}}<span class="error">{{.}}</span>{{end}}<br>
+			<label>{{.T "monte_carlo.seed"}}:</label>
+			<input type="text" name="seed" value="{{.Form.Value "seed"}}">
+			{{with .Form.Error "seed"}}<span class="error">{{.}}</span>{{end}}<br>
+			{{with .Form.Error ""}}<p class="error">{{.}}</p>{{end}}
+			<button type="submit">{{.T "common.calculate"}}</button>
 		</form>
+		{{with .Result}}
+		<div class="results">
+			<p>{{$.T "solar.pc_result"}}: {{$.Num.Format "pc" .Pc}}</p>
+			<p>{{$.T "solar.delta_result"}}: {{$.Num.Format "delta" .Delta}}</p>
+			{{with .Conditions}}
+			<p>{{$.T "solar.sigma_result"}}: {{$.Num.Format "sigma" .Sigma}} → {{$.Num.Format "sigma" .NewSigma}}</p>
+			<p>{{$.T "solar.band_result"}}: ±{{$.Num.Format "band" .Band}} (±{{$.Num.Format "tolerance" .Tolerance}})</p>
+			<p>{{$.T "solar.tariff_result"}}: {{$.Num.Format "tariff" .Tariff}}</p>
+			<p>{{$.T "solar.distribution"}}: {{$.T (print "distribution." .Distribution)}}</p>
+			{{end}}
+			<h3>{{$.T "solar.current"}}</h3>
+			<p>{{$.T "solar.energy_share"}}: {{$.Num.Format "energy_share" .Current.EnergyShare}}</p>
+			<p>{{$.T "solar.profit"}}: {{$.Num.Format "profit" .Current.Profit}}</p>
+			<p>{{$.T "solar.penalty"}}: {{$.Num.Format "penalty" .Current.Penalty}}</p>
+			<h3>{{$.T "solar.improved"}}</h3>
+			<p>{{$.T "solar.energy_share"}}: {{$.Num.Format "energy_share" .Improved.EnergyShare}}</p>
+			<p>{{$.T "solar.profit"}}: {{$.Num.Format "profit" .Improved.Profit}}</p>
+			<p>{{$.T "solar.penalty"}}: {{$.Num.Format "penalty" .Improved.Penalty}}</p>
+			<p><b>{{$.T "solar.gain" ($.Num.Format "gain" .Gain)}}</b></p>
+		</div>
+		{{end}}
+		{{with .Simulation}}
+		<div class="results">
+			<h3>{{$.T "monte_carlo.heading"}}</h3>
+			<p>{{$.T "monte_carlo.summary" .Days .Seed}}</p>
+			<table>
+				<tr>
+					<th></th><th>{{$.T "monte_carlo.mean"}}</th><th>P10</th><th>P50</th><th>P90</th><th>{{$.T "monte_carlo.loss"}}</th>
+				</tr>
+				{{range $.Histograms}}
+				<tr>
+					<th>{{$.T .Title}}</th><td>{{$.Num.Format "revenue" .Mean}}</td><td>{{$.Num.Format "revenue" .P10}}</td>
+					<td>{{$.Num.Format "revenue" .P50}}</td><td>{{$.Num.Format "revenue" .P90}}</td><td>{{$.Num.Format "loss" .Loss}}</td>
+				</tr>
+				{{end}}
+			</table>
+			{{range $.Histograms}}
+			<h4>{{$.T .Title}}</h4>
+			<div class="chart">
+				{{range .Bars}}<div style="height: {{.Height}}%" title="{{$.Num.Format "revenue" .From}} … {{$.Num.Format "revenue" .To}}: {{.Count}}"></div>{{end}}
+			</div>
+			<div class="axis"><span>{{$.Num.Format "revenue" .Min}}</span><span>{{$.Num.Format "revenue" .Max}}</span></div>
+			{{end}}
+			<p>{{$.T "monte_carlo.note"}}</p>
+		</div>
+		{{end}}
+		<p><a href="fit">{{.T "fit.title"}}</a> · <a href="hourly">{{.T "hourly.title"}}</a></p>
 		</div>
 		</body>
 		</html>
-		`)
-	} else if r.Method == http.MethodPost {
-		r.ParseForm()
-		pc, _ := strconv.ParseFloat(r.FormValue("pc"), 64)
-		delta, _ := strconv.ParseFloat(r.FormValue("delta"), 64)
-		result := calculateProfitOrPenalty(pc, delta)
-		fmt.Fprintf(w, "<pre>%s</pre>", result)
-	}
-}
-
-func calculateProfitOrPenalty(pc, delta float64) string {
-	B := 7.0
-	sigma := 1.0
-	newSigma := 0.25
-	lowerBound := pc - 0.25
-	upperBound := pc + 0.25
-
-	energyPercentage := (normalDistributionCDF(upperBound, pc, sigma) - normalDistributionCDF(lowerBound, pc, sigma)) * 100
-	profit1 := (pc * 24) * B * (energyPercentage / 100)
-	penalty1 := (pc * 24) * B * ((100 - energyPercentage) / 100)
-
-	newEnergyPercentage := (normalDistributionCDF(upperBound, pc, newSigma) - normalDistributionCDF(lowerBound, pc, newSigma)) * 100
-	profit2 := (pc * 24) * B * (newEnergyPercentage / 100)
-	penalty2 := (pc * 24) * B * ((100 - newEnergyPercentage) / 100)
-
-	res := profit2 - penalty2
-
-	return fmt.Sprintf(`
-Середньодобова потужність: %.2f МВт
-Похибка прогнозу: %.2f %%
-
-Відсоток енергії: %.2f %%
-Прибуток: %.2f тис. грн
-Штраф: %.2f тис. грн
-
-Відсоток енергії (новий σ): %.2f %%
-Прибуток: %.2f тис. грн
-Штраф: %.2f тис. грн
-
-Можна отримати %.2f тис. грн прибутку!
-`, pc, delta, energyPercentage, profit1, penalty1, newEnergyPercentage, profit2, penalty2, res)
-}
-
-func normalDistributionCDF(x, mean, stdDev float64) float64 {
-	return 0.5 * (1 + erf((x-mean)/(stdDev*math.Sqrt2)))
-}
-
-func erf(x float64) float64 {
-	sign := 1.0
-	if x < 0 {
-		sign = -1.0
-	}
-	x = math.Abs(x)
-	t := 1.0 / (1.0 + 0.3275911*x)
-	y := 1.0 - ((((1.061405429*t+-1.453152027)*t+1.421413741)*t+-0.284496736)*t+0.254829592)*t*math.Exp(-x*x)
-
-	return sign * y
-}
+`))
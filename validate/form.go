@@ -0,0 +1,128 @@
+package validate
+
+import (
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"Go_tutor/number"
+)
+
+// Form зчитує значення HTML-форми, запам'ятовує їх для повторного
+// відображення та збирає помилки розбору й перевірки.
+type Form struct {
+	values url.Values
+	Errors Errors
+	// Translate, якщо задано, перекладає рядки формату повідомлень.
+	Translate func(format string) string
+}
+
+// NewForm розбирає тіло запиту.
+func NewForm(r *http.Request) *Form {
+	r.ParseForm()
+	return &Form{values: r.Form, Errors: Errors{}}
+}
+
+// Prefilled створює форму з типовими значеннями полів, ще не надісланими
+// користувачем.
+func Prefilled(defaults url.Values) *Form {
+	return &Form{values: defaults, Errors: Errors{}}
+}
+
+// Value повертає введене значення поля.
+func (f *Form) Value(field string) string {
+	if f == nil {
+		return ""
+	}
+	return f.values.Get(field)
+}
+
+// Error повертає повідомлення про помилку поля.
+func (f *Form) Error(field string) string {
+	if f == nil {
+		return ""
+	}
+	m, ok := f.Errors[field]
+	if !ok {
+		return ""
+	}
+	if f.Translate != nil {
+		return m.Translate(f.Translate)
+	}
+	return m.String()
+}
+
+// Valid повідомляє, чи немає помилок.
+func (f *Form) Valid() bool {
+	return len(f.Errors) == 0
+}
+
+// String повертає значення текстового поля, вимагаючи, щоб воно було заповнене.
+func (f *Form) String(field string) string {
+	s := strings.TrimSpace(f.values.Get(field))
+	if s == "" {
+		f.Errors.Add(field, "value is required")
+	}
+	return s
+}
+
+// Float розбирає числове поле; десятковим роздільником може бути кома або
+// крапка.
+func (f *Form) Float(field string) float64 {
+	s := strings.TrimSpace(f.values.Get(field))
+	if s == "" {
+		f.Errors.Add(field, "value is required")
+		return 0
+	}
+	v, err := number.Parse(s)
+	if err != nil {
+		f.Errors.Add(field, "must be a number")
+		return 0
+	}
+	return v
+}
+
+// OptionalFloat розбирає необов'язкове числове поле; для порожнього поля
+// повертає nil.
+func (f *Form) OptionalFloat(field string) *float64 {
+	if strings.TrimSpace(f.values.Get(field)) == "" {
+		return nil
+	}
+	v := f.Float(field)
+	return &v
+}
+
+// Int розбирає цілочисельне поле.
+func (f *Form) Int(field string) int {
+	s := strings.TrimSpace(f.values.Get(field))
+	if s == "" {
+		f.Errors.Add(field, "value is required")
+		return 0
+	}
+	v, err := number.Parse(s)
+	if err != nil || v != math.Trunc(v) || math.Abs(v) > math.MaxInt32 {
+		f.Errors.Add(field, "must be a whole number")
+		return 0
+	}
+	return int(v)
+}
+
+// Fail записує помилку розрахунку: помилки полів — біля полів, решту — як
+// загальну помилку форми.
+func (f *Form) Fail(err error) {
+	f.FailField(General, err)
+}
+
+// FailField записує помилку біля поля field, якщо вона не містить власних
+// помилок полів. Текст помилки стає ключем перекладу; знаки % у ньому
+// екрануються, щоб не сприйматися як формат.
+func (f *Form) FailField(field string, err error) {
+	if errs, ok := Fields(err); ok {
+		for name, m := range errs {
+			f.Errors.Add(name, m.Format, m.Args...)
+		}
+		return
+	}
+	f.Errors.Add(field, strings.ReplaceAll(err.Error(), "%", "%%"))
+}
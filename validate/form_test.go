@@ -0,0 +1,41 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestFormFailField(t *testing.T) {
+	form := Prefilled(url.Values{})
+	form.FailField("plant", fmt.Errorf("plant %q: %w", "ТЕС 100%", errors.New("permit not found")))
+	want := `plant "ТЕС 100%": permit not found`
+	if got := form.Error("plant"); got != want {
+		t.Errorf("Error(plant) = %q, want %q", got, want)
+	}
+
+	// Текст без % перекладається як ключ каталогу
+	form.Translate = strings.ToUpper
+	form.FailField("year", errors.New("no tax rates for the year"))
+	if got := form.Error("year"); got != "NO TAX RATES FOR THE YEAR" {
+		t.Errorf("Error(year) = %q", got)
+	}
+
+	// Помилки полів лишаються біля своїх полів
+	errs := Errors{}
+	errs.Positive("q", 0)
+	form.FailField("plant", errs)
+	if got := form.Error("q"); got != "MUST BE GREATER THAN ZERO" {
+		t.Errorf("Error(q) = %q", got)
+	}
+}
+
+func TestFormFail(t *testing.T) {
+	form := Prefilled(url.Values{})
+	form.Fail(errors.New("load 50% exceeded"))
+	if got := form.Error(General); got != "load 50% exceeded" {
+		t.Errorf("Error() = %q", got)
+	}
+}
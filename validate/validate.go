@@ -0,0 +1,131 @@
+// Пакет validate перевіряє вхідні дані калькуляторів і збирає помилки
+// окремо для кожного поля.
+package validate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// General — ключ для помилок, що не стосуються окремого поля.
+const General = ""
+
+// Message — повідомлення про помилку. Format — англійський рядок формату,
+// який водночас є ключем у каталогах перекладів.
+type Message struct {
+	Format string
+	Args   []any
+}
+
+func (m Message) String() string {
+	return fmt.Sprintf(m.Format, m.Args...)
+}
+
+// Translate підставляє аргументи в перекладений рядок формату.
+func (m Message) Translate(translate func(format string) string) string {
+	return fmt.Sprintf(translate(m.Format), m.Args...)
+}
+
+func (m Message) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// Errors — повідомлення про помилки, згруповані за назвою поля.
+type Errors map[string]Message
+
+// Add записує помилку для поля, якщо для нього ще немає іншої.
+func (e Errors) Add(field, format string, args ...any) {
+	if _, exists := e[field]; !exists {
+		e[field] = Message{Format: format, Args: args}
+	}
+}
+
+// Translate повертає повідомлення, рядки формату яких перекладено
+// функцією translate.
+func (e Errors) Translate(translate func(format string) string) map[string]string {
+	out := make(map[string]string, len(e))
+	for field, m := range e {
+		out[field] = m.Translate(translate)
+	}
+	return out
+}
+
+// Err повертає nil, якщо помилок немає.
+func (e Errors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e Errors) Error() string {
+	return e.Message(nil)
+}
+
+// Message об'єднує помилки в один рядок "поле: повідомлення; …",
+// упорядкований за назвою поля. Рядки формату перекладаються функцією
+// translate, якщо її задано.
+func (e Errors) Message(translate func(format string) string) string {
+	fields := make([]string, 0, len(e))
+	for field := range e {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	parts := make([]string, 0, len(fields))
+	for _, field := range fields {
+		m := e[field].String()
+		if translate != nil {
+			m = e[field].Translate(translate)
+		}
+		if field != General {
+			m = field + ": " + m
+		}
+		parts = append(parts, m)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Fields повертає помилки полів, якщо err містить Errors.
+func Fields(err error) (Errors, bool) {
+	var errs Errors
+	ok := errors.As(err, &errs)
+	return errs, ok
+}
+
+// Positive перевіряє, що значення більше за нуль.
+func (e Errors) Positive(field string, v float64) {
+	if !(v > 0) || math.IsInf(v, 0) {
+		e.Add(field, "must be greater than zero")
+	}
+}
+
+// NonNegative перевіряє, що значення не від'ємне.
+func (e Errors) NonNegative(field string, v float64) {
+	if !(v >= 0) || math.IsInf(v, 0) {
+		e.Add(field, "must not be negative")
+	}
+}
+
+// Range перевіряє, що значення лежить у межах [min, max].
+func (e Errors) Range(field string, v, min, max float64) {
+	if !(v >= min && v <= max) {
+		e.Add(field, "must be between %g and %g", min, max)
+	}
+}
+
+// Percent перевіряє, що значення лежить у межах 0–100 %.
+func (e Errors) Percent(field string, v float64) {
+	e.Range(field, v, 0, 100)
+}
+
+// Fraction перевіряє коефіцієнт у межах (0, 1].
+func (e Errors) Fraction(field string, v float64) {
+	if !(v > 0 && v <= 1) {
+		e.Add(field, "must be greater than 0 and at most 1")
+	}
+}
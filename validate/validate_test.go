@@ -0,0 +1,23 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestErrorsMessage(t *testing.T) {
+	errs := Errors{}
+	errs.Positive("q", 0)
+	errs.Add(General, "composition is invalid")
+	errs.Range("a", 120, 0, 100)
+
+	want := "composition is invalid; a: must be between 0 and 100; q: must be greater than zero"
+	if got := errs.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	got := errs.Message(strings.ToUpper)
+	want = "COMPOSITION IS INVALID; a: MUST BE BETWEEN 0 AND 100; q: MUST BE GREATER THAN ZERO"
+	if got != want {
+		t.Errorf("Message() = %q, want %q", got, want)
+	}
+}